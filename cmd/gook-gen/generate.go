@@ -0,0 +1,695 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"maps"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// stringRules maps the argument-less string tags to the rules they call
+var stringRules = map[string]string{
+	"email":      "rules.Email()",
+	"url":        "rules.URL()",
+	"uuid":       "rules.UUID()",
+	"phone":      "rules.PhoneUS()",
+	"phone_intl": "rules.PhoneInternational()",
+	"creditcard": "rules.CreditCard()",
+	"ip":         "rules.IPAddress()",
+	"ipv4":       "rules.IPv4()",
+	"ipv6":       "rules.IPv6()",
+	"domain":     "rules.Domain()",
+	"hexcolor":   "rules.HexColor()",
+	"base64":     "rules.Base64()",
+	"json":       "rules.JSON()",
+}
+
+// stringArgs maps the string tags taking an argument to their rule functions
+var stringArgs = map[string]string{
+	"eq":       "gook.StringIs",
+	"contains": "gook.StringContains",
+	"suffix":   "gook.StringEndsWith",
+}
+
+// generator emits the rules of the struct types of one package
+type generator struct {
+	fset     *token.FileSet
+	pkg      string
+	specs    map[string]*ast.TypeSpec
+	files    map[string]map[string]string // import paths by name, per file
+	imports  map[string]map[string]bool   // names used in the generated code, per import path
+	order    []string                     // struct types in the order they were reached
+	fields   map[string][]string          // field rules by struct type
+	building map[string]bool
+}
+
+// generate returns the source of the rules for the named struct types of the
+// package in dir, or for all tagged struct types if names is empty
+func generate(dir string, names []string) ([]byte, error) {
+	g := &generator{
+		fset:     token.NewFileSet(),
+		specs:    make(map[string]*ast.TypeSpec),
+		files:    make(map[string]map[string]string),
+		imports:  make(map[string]map[string]bool),
+		fields:   make(map[string][]string),
+		building: make(map[string]bool),
+	}
+	if err := g.parse(dir); err != nil {
+		return nil, err
+	}
+	g.use("github.com/johan-st/gook", "gook")
+
+	if len(names) == 0 {
+		for name, spec := range g.specs {
+			if st, ok := spec.Type.(*ast.StructType); ok && spec.TypeParams == nil && tagged(st) {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no tagged struct types in %s", dir)
+	}
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		spec, ok := g.specs[name]
+		if !ok {
+			return nil, fmt.Errorf("type %s not found in %s", name, dir)
+		}
+		if _, ok := spec.Type.(*ast.StructType); !ok || spec.TypeParams != nil {
+			return nil, fmt.Errorf("type %s is not a non-generic struct", name)
+		}
+		if err := g.structRule(name); err != nil {
+			return nil, err
+		}
+	}
+	return g.source()
+}
+
+// parse collects the type declarations of the non-test, non-generated files in
+// dir that the build constraints select for the current platform
+func (g *generator) parse(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil {
+			return err
+		} else if !match {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if ast.IsGenerated(file) {
+			continue
+		}
+		if g.pkg != "" && file.Name.Name != g.pkg {
+			return fmt.Errorf("found packages %s and %s in %s", g.pkg, file.Name.Name, dir)
+		}
+		g.pkg = file.Name.Name
+		g.files[g.fset.File(file.Pos()).Name()] = fileImports(file)
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.GenDecl); ok && decl.Tok == token.TYPE {
+				for _, spec := range decl.Specs {
+					spec := spec.(*ast.TypeSpec)
+					g.specs[spec.Name.Name] = spec
+				}
+			}
+		}
+	}
+	if g.pkg == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+	return nil
+}
+
+// fileImports returns the import paths of file by the name they are used
+// with, under "." for dot imports
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := importName(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// importName returns the name a package is assumed to have when imported
+// without one: the last element of path that is not a major version, without
+// a "go-" prefix and cut at the first character not allowed in identifiers
+func importName(path string) string {
+	elems := strings.Split(path, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elems[len(elems)-2]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !(unicode.IsLetter(r) || r == '_' || unicode.IsDigit(r))
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// use records that the generated code refers to the package at path by name
+func (g *generator) use(path, name string) {
+	if g.imports[path] == nil {
+		g.imports[path] = make(map[string]bool)
+	}
+	g.imports[path][name] = true
+}
+
+// useImports records the imports type expression t refers to, by the names
+// they have in the file t is written in
+func (g *generator) useImports(t ast.Expr) error {
+	imports := g.files[g.fset.File(t.Pos()).Name()]
+	var err error
+	ast.Inspect(t, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			pkg, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			path, ok := imports[pkg.Name]
+			if !ok {
+				err = fmt.Errorf("%s: package %s is not imported", types.ExprString(t), pkg.Name)
+				return false
+			}
+			g.use(path, pkg.Name)
+			return false
+		case *ast.Ident:
+			// Types from a dot import look like types of the package
+			if _, dot := imports["."]; dot && g.specs[n.Name] == nil && types.Universe.Lookup(n.Name) == nil {
+				err = fmt.Errorf("%s: types of dot imports are not supported", types.ExprString(t))
+				return false
+			}
+		}
+		return err == nil
+	})
+	return err
+}
+
+// tagged returns true if a field of st has a gook tag
+func tagged(st *ast.StructType) bool {
+	for _, field := range st.Fields.List {
+		if _, ok := tagOf(field, "gook"); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func tagOf(field *ast.Field, key string) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	return reflect.StructTag(tag).Lookup(key)
+}
+
+// structRule generates the field rules of struct type name, unless already done
+func (g *generator) structRule(name string) error {
+	if _, ok := g.fields[name]; ok {
+		return nil
+	}
+
+	// Register before generating so recursive fields find it
+	g.fields[name] = nil
+	g.order = append(g.order, name)
+	g.building[name] = true
+	defer delete(g.building, name)
+
+	fields, err := g.structFields(name, "v", nil, g.specs[name].Type.(*ast.StructType))
+	if err != nil {
+		return err
+	}
+	g.fields[name] = fields
+	return nil
+}
+
+// empty returns true if values of struct type name have nothing to validate
+func (g *generator) empty(name string) bool {
+	return !g.building[name] && len(g.fields[name]) == 0
+}
+
+// structFields generates the field rules of st for the struct type name,
+// reading fields through sel. Fields of embedded structs are included, and
+// are skipped while one of the embedded pointers in guards is nil
+func (g *generator) structFields(name, sel string, guards []string, st *ast.StructType) ([]string, error) {
+	var fields []string
+	for _, field := range st.Fields.List {
+		tag, isTagged := tagOf(field, "gook")
+		if tag == "-" {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if isTagged {
+				return nil, g.errorf(field, "%s: tags on embedded fields are not supported", name)
+			}
+			embedded, isPointer := field.Type, false
+			if star, ok := embedded.(*ast.StarExpr); ok {
+				embedded, isPointer = star.X, true
+			}
+			ident, ok := embedded.(*ast.Ident)
+			if !ok {
+				// The tags of its fields are out of reach
+				return nil, g.errorf(field, "%s: embedded %s is not a type of the package; tag it `gook:\"-\"` to skip its fields",
+					name, types.ExprString(field.Type))
+			}
+			spec := g.specs[ident.Name]
+			if spec == nil {
+				continue
+			}
+			if inner, ok := spec.Type.(*ast.StructType); ok {
+				path := sel + "." + ident.Name
+				next := guards
+				if isPointer {
+					next = append(slices.Clip(guards), path)
+				}
+				promoted, err := g.structFields(name, path, next, inner)
+				if err != nil {
+					return nil, err
+				}
+				fields = append(fields, promoted...)
+			}
+			continue
+		}
+
+		for _, ident := range field.Names {
+			if !ident.IsExported() {
+				continue
+			}
+			rule, err := g.valueRule(field.Type, tag)
+			if err != nil {
+				return nil, g.errorf(field, "%s.%s: %v", name, ident.Name, err)
+			}
+			if rule == "" {
+				continue
+			}
+			fields = append(fields, g.fieldRule(fieldName(field, ident.Name), name, sel+"."+ident.Name,
+				types.ExprString(field.Type), guards, rule))
+		}
+	}
+	return fields, nil
+}
+
+// fieldRule returns the rule applying rule to the field of struct type name at
+// path. A field promoted through embedded pointers is read through a pointer
+// that is nil while one of guards is, like tags.Build skips it
+func (g *generator) fieldRule(field, name, path, expr string, guards []string, rule string) string {
+	if len(guards) == 0 {
+		return fmt.Sprintf("gook.Field(%q, func(v %s) %s { return %s }, %s)", field, name, expr, path, rule)
+	}
+	g.use("github.com/johan-st/gook/tags", "tags")
+	return fmt.Sprintf("gook.Field(%q, func(v %s) *%s {\nif %s == nil {\nreturn nil\n}\nreturn &%s\n}, gook.Elements(\"optional\", tags.Present[%s], %s))",
+		field, name, expr, strings.Join(guards, " == nil || "), path, expr, rule)
+}
+
+func (g *generator) errorf(node ast.Node, format string, args ...any) error {
+	return fmt.Errorf("%v: %s", g.fset.Position(node.Pos()), fmt.Sprintf(format, args...))
+}
+
+// fieldName returns the json name of a field, or its Go name
+func fieldName(field *ast.Field, goName string) string {
+	tag, _ := tagOf(field, "json")
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" || name == "-" {
+		return goName
+	}
+	return name
+}
+
+// valueRule returns the rule expression for a value of type t with the given
+// tag, or "" if there is nothing to validate
+func (g *generator) valueRule(t ast.Expr, tag string) (string, error) {
+	if err := g.useImports(t); err != nil {
+		return "", err
+	}
+	own, dive, hasDive := splitDive(tag)
+	info := g.resolve(t)
+	checks, err := g.tagRules(info, own)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case info.kind == kindStruct:
+		if err := g.structRule(info.name); err != nil {
+			return "", err
+		}
+		if !g.empty(info.name) {
+			checks = append(checks, funcName(info.name)+"()")
+		}
+
+	case info.kind == kindPointer:
+		if err := g.structRule(info.name); err != nil {
+			return "", err
+		}
+		if !g.empty(info.name) {
+			g.use("github.com/johan-st/gook/tags", "tags")
+			checks = append(checks, fmt.Sprintf(`gook.Elements("optional", tags.Present[%s], %s())`, info.name, funcName(info.name)))
+		}
+
+	case info.kind == kindSlice || info.kind == kindMap:
+		elem, err := g.valueRule(info.elem, dive)
+		if err != nil {
+			return "", err
+		}
+		if elem != "" {
+			g.use("github.com/johan-st/gook/tags", "tags")
+			items := "tags.Indexed"
+			if info.kind == kindMap {
+				if !g.ordered(info.key) {
+					return "", fmt.Errorf("elements of %s are visited in key order, which %s keys do not have",
+						info.expr, types.ExprString(info.key))
+				}
+				items = "tags.Sorted"
+			}
+			checks = append(checks, fmt.Sprintf(`gook.Elements("elements", %s[%s], %s)`, items, info.expr, elem))
+		}
+
+	case hasDive:
+		return "", fmt.Errorf("dive on %s, which is not a slice or map", info.expr)
+	}
+
+	switch len(checks) {
+	case 0:
+		return "", nil
+	case 1:
+		return checks[0], nil
+	default:
+		return "gook.All(\n" + strings.Join(checks, ",\n") + ",\n)", nil
+	}
+}
+
+// ordered returns true if values of type t are ordered as far as the source
+// tells. Types of other packages are assumed to be
+func (g *generator) ordered(t ast.Expr) bool {
+	if _, ok := t.(*ast.SelectorExpr); ok {
+		return true
+	}
+	kind := g.resolve(t).kind
+	return kind == kindString || kind == kindNumber
+}
+
+// tagRules returns the rule expressions for the comma separated tag names in tag
+func (g *generator) tagRules(info typeInfo, tag string) ([]string, error) {
+	var checks []string
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		rule, err := g.tagRule(info, name, arg)
+		if err != nil {
+			return nil, fmt.Errorf("tag %q: %w", part, err)
+		}
+		checks = append(checks, rule)
+	}
+	return checks, nil
+}
+
+// splitDive splits tag at its first "dive" into the tags of a value and the
+// tags of its elements
+func splitDive(tag string) (string, string, bool) {
+	parts := strings.Split(tag, ",")
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "dive" {
+			return strings.Join(parts[:i], ","), strings.Join(parts[i+1:], ","), true
+		}
+	}
+	return tag, "", false
+}
+
+// tagRule returns the rule expression for one tag on a value described by info
+func (g *generator) tagRule(info typeInfo, name, arg string) (string, error) {
+	if rule, ok := stringRules[name]; ok {
+		if arg != "" {
+			return "", fmt.Errorf("takes no argument")
+		}
+		g.use("github.com/johan-st/gook/rules", "rules")
+		return g.stringRule(info, rule)
+	}
+	if rule, ok := stringArgs[name]; ok {
+		return g.stringRule(info, rule+"("+strconv.Quote(arg)+")")
+	}
+
+	switch name {
+	case "required":
+		g.use("github.com/johan-st/gook/tags", "tags")
+		switch info.kind {
+		case kindSlice, kindBytes:
+			return "tags.RequiredSlice[" + info.expr + "]()", nil
+		case kindMap:
+			return "tags.RequiredMap[" + info.expr + "]()", nil
+		}
+		if !info.comparable {
+			return "", fmt.Errorf("needs a comparable, slice or map field, got %s", info.expr)
+		}
+		return "tags.Required[" + info.expr + "]()", nil
+
+	case "len":
+		min, max, err := parseRange(arg)
+		if err != nil {
+			return "", err
+		}
+		if max == "" {
+			g.use("math", "math")
+			max = "math.MaxInt"
+		}
+		switch info.kind {
+		case kindString:
+			return g.stringRule(info, fmt.Sprintf("gook.StringLength(%s, %s)", min, max))
+		case kindBytes:
+			return g.bytesRule(info, fmt.Sprintf("gook.All(gook.BytesMin(%s), gook.BytesMax(%s))", min, max))
+		case kindSlice:
+			g.use("github.com/johan-st/gook/tags", "tags")
+			return fmt.Sprintf("tags.Len[%s](%s, %s)", info.expr, min, max), nil
+		case kindMap:
+			g.use("github.com/johan-st/gook/tags", "tags")
+			return fmt.Sprintf("tags.MapLen[%s](%s, %s)", info.expr, min, max), nil
+		}
+		return "", fmt.Errorf("needs a string, slice or map field, got %s", info.expr)
+
+	case "min", "max":
+		if info.kind != kindNumber {
+			return "", fmt.Errorf("needs a numeric field, got %s", info.expr)
+		}
+		bound, err := parseBound(info, arg)
+		if err != nil {
+			return "", err
+		}
+		g.use("github.com/johan-st/gook/tags", "tags")
+		return fmt.Sprintf("tags.%s[%s](%s)", strings.ToUpper(name[:1])+name[1:], info.expr, bound), nil
+
+	case "utf8":
+		switch info.kind {
+		case kindString:
+			g.use("github.com/johan-st/gook/tags", "tags")
+			return g.stringRule(info, "tags.UTF8()")
+		case kindBytes:
+			return g.bytesRule(info, "gook.BytesEncoding(gook.EncodingUTF8)")
+		}
+		return "", fmt.Errorf("needs a string or []byte field, got %s", info.expr)
+	}
+
+	// Any other tag calls a rule function of the package
+	if !isTagName(name) {
+		return "", fmt.Errorf("invalid tag name %q", name)
+	}
+	if arg == "" {
+		return camelCase(name) + "Rule()", nil
+	}
+	return camelCase(name) + "Rule(" + strconv.Quote(arg) + ")", nil
+}
+
+// stringRule adapts a rule expression on strings to the type described by info
+func (g *generator) stringRule(info typeInfo, rule string) (string, error) {
+	if info.kind != kindString {
+		return "", fmt.Errorf("needs a string field, got %s", info.expr)
+	}
+	if !info.named {
+		return rule, nil
+	}
+	g.use("github.com/johan-st/gook/tags", "tags")
+	return "tags.String[" + info.expr + "](" + rule + ")", nil
+}
+
+// bytesRule adapts a rule expression on []byte to the type described by info
+func (g *generator) bytesRule(info typeInfo, rule string) (string, error) {
+	if !info.named {
+		return rule, nil
+	}
+	g.use("github.com/johan-st/gook/tags", "tags")
+	return "tags.Bytes[" + info.expr + "](" + rule + ")", nil
+}
+
+// parseRange splits len=n, len=min..max, len=min.. and len=..max into its
+// bounds, with "" for a missing max
+func parseRange(arg string) (string, string, error) {
+	lo, hi, isRange := strings.Cut(arg, "..")
+	if !isRange {
+		hi = lo
+	}
+	if lo == "" && hi == "" {
+		return "", "", fmt.Errorf("missing length")
+	}
+	for _, bound := range []string{lo, hi} {
+		if _, err := strconv.Atoi(bound); bound != "" && err != nil {
+			return "", "", fmt.Errorf("invalid length %q", arg)
+		}
+	}
+	if lo == "" {
+		lo = "0"
+	}
+	return lo, hi, nil
+}
+
+// intSizes are the bit sizes of the predeclared integer types
+var intSizes = map[string]int{
+	"int": strconv.IntSize, "int8": 8, "int16": 16, "int32": 32, "rune": 32, "int64": 64,
+	"uint": strconv.IntSize, "uint8": 8, "byte": 8, "uint16": 16, "uint32": 32, "uint64": 64, "uintptr": 64,
+}
+
+// parseBound returns the min or max bound arg as a constant of the numeric
+// type described by info
+func parseBound(info typeInfo, arg string) (string, error) {
+	if info.basic == "float32" || info.basic == "float64" {
+		bits := 64
+		if info.basic == "float32" {
+			bits = 32
+		}
+		f, err := strconv.ParseFloat(arg, bits)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", fmt.Errorf("invalid %s bound %q", info.basic, arg)
+		}
+		return strconv.FormatFloat(f, 'g', -1, bits), nil
+	}
+
+	bits := intSizes[info.basic]
+	if strings.HasPrefix(info.basic, "u") || info.basic == "byte" {
+		n, err := strconv.ParseUint(arg, 10, bits)
+		if err != nil {
+			return "", fmt.Errorf("invalid %s bound %q", info.basic, arg)
+		}
+		return strconv.FormatUint(n, 10), nil
+	}
+	n, err := strconv.ParseInt(arg, 10, bits)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s bound %q", info.basic, arg)
+	}
+	return strconv.FormatInt(n, 10), nil
+}
+
+// source returns the formatted generated file
+func (g *generator) source() ([]byte, error) {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gook-gen; DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.pkg)
+	paths := make(map[string]string) // import paths by name
+	for _, path := range slices.Sorted(maps.Keys(g.imports)) {
+		// A package used by different names is imported once for each
+		for _, name := range slices.Sorted(maps.Keys(g.imports[path])) {
+			if other, ok := paths[name]; ok {
+				return nil, fmt.Errorf("packages %q and %q are both imported as %s", other, path, name)
+			}
+			paths[name] = path
+			if name == importName(path) {
+				fmt.Fprintf(&b, "%q\n", path)
+			} else {
+				fmt.Fprintf(&b, "%s %q\n", name, path)
+			}
+		}
+	}
+	b.WriteString(")\n\n")
+
+	// Rules are allocated first and filled in by init so recursive types can
+	// refer to each other
+	b.WriteString("var (\n")
+	for _, name := range g.order {
+		fmt.Fprintf(&b, "%s = &gook.Rule[%s]{}\n", varName(name), name)
+	}
+	b.WriteString(")\n\nfunc init() {\n")
+	for _, name := range g.order {
+		// The type argument is explicit as a struct without field rules
+		// leaves nothing to infer it from
+		fmt.Fprintf(&b, "*%s = *gook.Object[%s](%q,\n", varName(name), name, name)
+		for _, field := range g.fields[name] {
+			b.WriteString(field + ",\n")
+		}
+		b.WriteString(")\n")
+	}
+	b.WriteString("}\n")
+
+	for _, name := range g.order {
+		fmt.Fprintf(&b, "\n// %s returns the rule for %s built from its gook struct tags\n", funcName(name), name)
+		fmt.Fprintf(&b, "func %s() *gook.Rule[%s] {\nreturn %s\n}\n", funcName(name), name, varName(name))
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// funcName returns the name of the function returning the rule for type name
+func funcName(name string) string {
+	return name + "Rule"
+}
+
+// varName returns the name of the variable holding the rule for type name
+func varName(name string) string {
+	return "gen" + strings.ToUpper(name[:1]) + name[1:] + "Rule"
+}
+
+func isTagName(name string) bool {
+	for i, r := range name {
+		if !(unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// camelCase turns a snake_case tag name into a Go identifier
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
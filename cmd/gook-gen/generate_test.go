@@ -0,0 +1,268 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// generateFrom writes src to a package directory and runs the generator on it
+func generateFrom(t *testing.T, src string, names ...string) (string, error) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := generate(dir, names)
+	return string(out), err
+}
+
+func TestGenerate(t *testing.T) {
+	out, err := generateFrom(t, `package shop
+
+type Code string
+
+type Order struct {
+	ID    Code              `+"`"+`json:"id" gook:"required,len=8"`+"`"+`
+	Qty   int               `+"`"+`json:"qty" gook:"min=1,max=99"`+"`"+`
+	Items []Item            `+"`"+`json:"items" gook:"len=1.."`+"`"+`
+	Ship  *Address          `+"`"+`json:"ship"`+"`"+`
+	Meta  map[string]string `+"`"+`json:"meta" gook:"dive,even"`+"`"+`
+}
+
+type Item struct {
+	SKU string `+"`"+`json:"sku" gook:"uuid"`+"`"+`
+}
+
+type Address struct {
+	City string `+"`"+`json:"city" gook:"required"`+"`"+`
+	Next *Address
+}
+`, "Order")
+	if err != nil {
+		t.Fatalf("Expected generation to succeed, got %v", err)
+	}
+
+	for _, want := range []string{
+		"// Code generated by gook-gen; DO NOT EDIT.",
+		`"math"`,
+		`gook.Field("id", func(v Order) Code { return v.ID }, gook.All(`,
+		"tags.Required[Code](),",
+		"tags.String[Code](gook.StringLength(8, 8)),",
+		"tags.Min[int](1),",
+		"tags.Len[[]Item](1, math.MaxInt),",
+		`gook.Elements("elements", tags.Indexed[[]Item], ItemRule())`,
+		`gook.Elements("optional", tags.Present[Address], AddressRule())`,
+		`gook.Elements("elements", tags.Sorted[map[string]string], evenRule())`,
+		`gook.Field("sku", func(v Item) string { return v.SKU }, rules.UUID())`,
+		`gook.Field("Next", func(v Address) *Address { return v.Next }`,
+		"*genOrderRule = *gook.Object[Order](\"Order\",",
+		"func OrderRule() *gook.Rule[Order] {",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+// buildFixture is a package using imports in the ways the generated code must
+// reproduce: aliased, unnamed, in another file, inside types and recursively.
+// It also embeds structs through pointers, which must be skipped while nil,
+// and has a file excluded by its build constraints
+var buildFixture = map[string]string{
+	"event.go": `package fixture
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	link "net/url"
+
+	"github.com/johan-st/gook"
+)
+
+type Event struct {
+	At       time.Time            ` + "`gook:\"required\"`" + `
+	Link     link.URL             ` + "`gook:\"required\"`" + `
+	Score    float64              ` + "`gook:\"min=0.5,max=1e3\"`" + `
+	Retries  uint8                ` + "`gook:\"max=255\"`" + `
+	Count    int                  ` + "`gook:\"even,diverse\"`" + `
+	Log      Stamps               ` + "`gook:\"len=1..,dive,required\"`" + `
+	ByDay    map[string]time.Time ` + "`gook:\"dive,required\"`" + `
+	Parent   *Event
+	Children []Event
+	Meta     Meta                 ` + "`gook:\"required\"`" + `
+	Extra    Meta
+}
+
+type Meta struct {
+	Source string
+}
+
+// diverseRule has a name starting with dive
+func diverseRule() *gook.Rule[int] {
+	return evenRule()
+}
+
+func evenRule() *gook.Rule[int] {
+	return gook.Test("even", func(ctx context.Context, n int) error {
+		if n%2 != 0 {
+			return errors.New("odd")
+		}
+		return nil
+	})
+}
+`,
+	"stamps.go": `package fixture
+
+import clock "time"
+
+type Stamps []clock.Time
+`,
+	// A helper run with go run must not change the package name
+	"tools.go": `//go:build ignore
+
+package main
+
+func main() {}
+`,
+	"member.go": `package fixture
+
+type Member struct {
+	*Base
+	Nick   string         ` + "`gook:\"len=..8\"`" + `
+	Levels map[int]string ` + "`gook:\"dive,required\"`" + `
+}
+
+type Base struct {
+	Name string ` + "`json:\"name\" gook:\"required\"`" + `
+	*Inner
+}
+
+type Inner struct {
+	Code string ` + "`gook:\"required\"`" + `
+}
+`,
+	// The generated rules must report what tags.Build does
+	"member_test.go": `package fixture
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/johan-st/gook/tags"
+)
+
+func TestMemberRule(t *testing.T) {
+	built := tags.MustBuild[Member]()
+	for _, m := range []Member{
+		{Nick: "far too long"},
+		{Base: &Base{}},
+		{Base: &Base{Name: "ada", Inner: &Inner{}}},
+		{Levels: map[int]string{10: "", 2: ""}},
+	} {
+		got, ok := MemberRule().Validate(context.Background(), m)
+		want, wantOK := built.Validate(context.Background(), m)
+		if ok != wantOK || !reflect.DeepEqual(got.Flatten(), want.Flatten()) {
+			t.Errorf("Expected %v for %+v, got %v", want.Flatten(), m, got.Flatten())
+		}
+	}
+}
+`,
+}
+
+func TestGeneratedCodeBuilds(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a module")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+	root, err := filepath.Abs("../..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/fixture\n\ngo 1.23\n\nrequire github.com/johan-st/gook v0.0.0\n\nreplace github.com/johan-st/gook => " + root + "\n",
+	}
+	for name, src := range buildFixture {
+		files[name] = src
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := generate(dir, []string{"Event", "Member"})
+	if err != nil {
+		t.Fatalf("Expected generation to succeed, got %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "gook_gen.go"), out, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, args := range [][]string{{"build", "./..."}, {"vet", "./..."}, {"test", "./..."}} {
+		cmd := exec.Command(gobin, args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("Expected go %s to succeed, got %v:\n%s\ngenerated:\n%s", args[0], err, output, out)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want string
+	}{
+		{"wrong type", "package p\ntype T struct {\n\tN int `gook:\"email\"`\n}\n", "types.go:3:2: T.N: tag \"email\": needs a string field, got int"},
+		{"bad dive", "package p\ntype T struct {\n\tS string `gook:\"dive,email\"`\n}\n", "dive on string"},
+		{"bad length", "package p\ntype T struct {\n\tS string `gook:\"len=x\"`\n}\n", `invalid length "x"`},
+		{"bad tag name", "package p\ntype T struct {\n\tS string `gook:\"a-b\"`\n}\n", `invalid tag name "a-b"`},
+		{"fractional int bound", "package p\ntype T struct {\n\tN int `gook:\"min=1.5\"`\n}\n", `invalid int bound "1.5"`},
+		{"negative uint bound", "package p\ntype T struct {\n\tN uint `gook:\"min=-1\"`\n}\n", `invalid uint bound "-1"`},
+		{"overflowing bound", "package p\ntype T struct {\n\tN int8 `gook:\"max=300\"`\n}\n", `invalid int8 bound "300"`},
+		{"infinite bound", "package p\ntype T struct {\n\tF float64 `gook:\"max=Inf\"`\n}\n", `invalid float64 bound "Inf"`},
+		{"unknown package", "package p\ntype T struct {\n\tD time.Duration `gook:\"required\"`\n}\n", "package time is not imported"},
+		{"dot import", "package p\nimport . \"time\"\ntype T struct {\n\tD Duration `gook:\"required\"`\n}\n", "types of dot imports are not supported"},
+		{"import conflict", "package p\nimport rules \"strings\"\ntype T struct {\n\tB rules.Builder `gook:\"required\"`\n\tE string `gook:\"email\"`\n}\n", `are both imported as rules`},
+		{"required func", "package p\ntype T struct {\n\tF func() `gook:\"required\"`\n}\n", "needs a comparable, slice or map field, got func()"},
+		{"required incomparable struct", "package p\ntype T struct {\n\tC Cmp `gook:\"required\"`\n}\ntype Cmp struct{ S []string }\n", "needs a comparable, slice or map field, got Cmp"},
+		{"embedded from another package", "package p\nimport \"time\"\ntype T struct {\n\t*time.Location\n\tS string `gook:\"email\"`\n}\n", "embedded *time.Location is not a type of the package"},
+		{"unordered map keys", "package p\ntype T struct {\n\tM map[bool]string `gook:\"dive,email\"`\n}\n", "which bool keys do not have"},
+		{"no tags", "package p\ntype T struct{ S string }\n", "no tagged struct types"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := generateFrom(t, tt.src); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+
+	if _, err := generateFrom(t, "package p\ntype T int\n", "T"); err == nil {
+		t.Error("Expected non-struct type to be rejected")
+	}
+
+	dir := t.TempDir()
+	for name, src := range map[string]string{
+		"a.go": "package a\ntype T struct {\n\tS string `gook:\"email\"`\n}\n",
+		"b.go": "package b\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := generate(dir, nil); err == nil || !strings.Contains(err.Error(), "found packages a and b") {
+		t.Errorf("Expected mixed packages to be rejected, got %v", err)
+	}
+}
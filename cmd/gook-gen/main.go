@@ -0,0 +1,61 @@
+// Command gook-gen generates validation rules from `gook` struct tags, so
+// tagged structs can be validated without reflection at runtime.
+//
+// Add a directive to a file in the package and run go generate:
+//
+//	//go:generate go run github.com/johan-st/gook/cmd/gook-gen -type=Signup
+//
+// For each struct type it writes a function returning its *gook.Rule, e.g.
+// SignupRule for Signup, to gook_gen.go in the package directory. Struct types
+// of the package used by their fields are generated with them, so run it once
+// per package. Without -type it generates rules for every tagged struct.
+//
+// The tags are the ones package tags understands. Any other tag refers to a
+// function in the package named after the tag with a Rule suffix, e.g.
+// `gook:"even"` calls evenRule() and `gook:"multiple_of=3"` calls
+// multipleOfRule("3"). It must return a *gook.Rule for the field type;
+// a tag without such a function is a compile error in the generated code.
+//
+// Fields promoted from embedded structs of the package are validated like
+// tags.Build does, and skipped while an embedded pointer is nil. The tags of
+// structs embedded from other packages cannot be read, so such fields must
+// be tagged `gook:"-"`.
+//
+// As the generated code compares values with ==, required is rejected on
+// fields that are not comparable, other than slices and maps. Map elements
+// are visited in key order, so dive needs map keys of string or number types.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma separated struct types to generate rules for (default: all tagged structs)")
+	output := flag.String("output", "gook_gen.go", "name of the generated file")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("gook-gen: ")
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	var types []string
+	if *typeNames != "" {
+		types = strings.Split(*typeNames, ",")
+	}
+
+	src, err := generate(dir, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, *output), src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// typeKind is what the generator knows about a type from the source alone
+type typeKind int
+
+const (
+	kindOther   typeKind = iota
+	kindString           // string or a type based on it
+	kindNumber           // integer or floating point type
+	kindBool             // bool or a type based on it
+	kindBytes            // []byte or a type based on it
+	kindSlice            // slice of anything but bytes
+	kindMap              // map
+	kindStruct           // struct type declared in the package
+	kindPointer          // pointer to a struct type declared in the package
+)
+
+var basicKinds = map[string]typeKind{
+	"string": kindString,
+	"bool":   kindBool,
+	"int":    kindNumber, "int8": kindNumber, "int16": kindNumber, "int32": kindNumber, "int64": kindNumber,
+	"uint": kindNumber, "uint8": kindNumber, "uint16": kindNumber, "uint32": kindNumber, "uint64": kindNumber,
+	"uintptr": kindNumber, "byte": kindNumber, "rune": kindNumber,
+	"float32": kindNumber, "float64": kindNumber,
+}
+
+// typeInfo describes a field or element type
+type typeInfo struct {
+	kind  typeKind
+	expr  string   // the type as written in the source
+	named bool     // a type declared in the package based on a predeclared one
+	elem  ast.Expr // element type of slices and maps
+	key   ast.Expr // key type of maps
+	name  string   // struct type name for kindStruct and kindPointer
+	basic string   // the predeclared type a string, number or bool type is based on
+
+	comparable bool // values can be compared with ==
+}
+
+// resolve describes type t, following type declarations of the package
+func (g *generator) resolve(t ast.Expr) typeInfo {
+	info := typeInfo{expr: types.ExprString(t), comparable: g.comparable(t, map[string]bool{})}
+	switch t := t.(type) {
+	case *ast.ParenExpr:
+		inner := g.resolve(t.X)
+		inner.expr = info.expr
+		return inner
+
+	case *ast.Ident:
+		if kind, ok := basicKinds[t.Name]; ok {
+			info.kind, info.basic = kind, t.Name
+			return info
+		}
+		spec, ok := g.specs[t.Name]
+		if !ok || spec.TypeParams != nil {
+			return info
+		}
+		if _, ok := spec.Type.(*ast.StructType); ok {
+			if spec.Assign.IsValid() {
+				return info
+			}
+			info.kind, info.name = kindStruct, t.Name
+			return info
+		}
+		underlying := g.resolve(spec.Type)
+		if underlying.kind == kindStruct || underlying.kind == kindPointer {
+			// A new type over a struct has no generated rule of its own
+			return info
+		}
+		underlying.expr = info.expr
+		underlying.named = !spec.Assign.IsValid() || underlying.named
+		return underlying
+
+	case *ast.StarExpr:
+		if inner := g.resolve(t.X); inner.kind == kindStruct {
+			info.kind, info.name = kindPointer, inner.name
+		}
+
+	case *ast.ArrayType:
+		if t.Len != nil {
+			return info
+		}
+		if elem := types.ExprString(t.Elt); elem == "byte" || elem == "uint8" {
+			info.kind = kindBytes
+			return info
+		}
+		info.kind, info.elem = kindSlice, t.Elt
+
+	case *ast.MapType:
+		info.kind, info.elem, info.key = kindMap, t.Value, t.Key
+	}
+	return info
+}
+
+// comparable returns true if values of type t can be compared with ==, as far
+// as the source tells. Types of other packages are assumed to be comparable
+func (g *generator) comparable(t ast.Expr, seen map[string]bool) bool {
+	switch t := t.(type) {
+	case *ast.ParenExpr:
+		return g.comparable(t.X, seen)
+
+	case *ast.Ident:
+		spec, ok := g.specs[t.Name]
+		if !ok || seen[t.Name] || spec.TypeParams != nil {
+			return true
+		}
+		seen[t.Name] = true
+		return g.comparable(spec.Type, seen)
+
+	case *ast.FuncType, *ast.MapType:
+		return false
+
+	case *ast.ArrayType:
+		return t.Len != nil && g.comparable(t.Elt, seen)
+
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if !g.comparable(field.Type, seen) {
+				return false
+			}
+		}
+	}
+	return true
+}
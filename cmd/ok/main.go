@@ -25,12 +25,14 @@ func getRunFuncs() (map[string]func(string), []string) {
 	funcEmail := func(testString string) { examples.Email(testString) }
 	funcNumeric := func(testString string) { examples.Numeric(testString) }
 	funcWip := func(testString string) { examples.Wip(testString) }
+	funcSignup := func(testString string) { examples.GeneratedSignup(testString) }
 
 	runValues := map[string]func(string){
 		"basic": funcRunBasicExamples,
 		"email": funcEmail,
 		"num": funcNumeric,
 		"wip": funcWip,
+		"signup": funcSignup,
 	}
 	keys := make([]string, 0, len(runValues))
 	for k := range runValues {
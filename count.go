@@ -0,0 +1,145 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+)
+
+// OneOf creates a rule that passes if exactly one of the given rules passes
+func OneOf[T any](rules ...*Rule[T]) *Rule[T] {
+	return &Rule[T]{
+		Label:    "one-of",
+		Kind:     KindOneOf,
+		Children: rules,
+		Count:    1,
+	}
+}
+
+// AtLeast creates a rule that passes if at least n of the given rules pass
+func AtLeast[T any](n int, rules ...*Rule[T]) *Rule[T] {
+	return &Rule[T]{
+		Label:    "at-least",
+		Kind:     KindAtLeast,
+		Children: rules,
+		Count:    n,
+	}
+}
+
+// AtMost creates a rule that passes if at most n of the given rules pass
+func AtMost[T any](n int, rules ...*Rule[T]) *Rule[T] {
+	return &Rule[T]{
+		Label:    "at-most",
+		Kind:     KindAtMost,
+		Children: rules,
+		Count:    n,
+	}
+}
+
+// Exactly creates a rule that passes if exactly n of the given rules pass
+func Exactly[T any](n int, rules ...*Rule[T]) *Rule[T] {
+	return &Rule[T]{
+		Label:    "exactly",
+		Kind:     KindExactly,
+		Children: rules,
+		Count:    n,
+	}
+}
+
+// countBounds returns the accepted range of passing children
+func (r *Rule[T]) countBounds() (int, int) {
+	switch r.Kind {
+	case KindAtLeast:
+		return r.Count, len(r.Children)
+	case KindAtMost:
+		return 0, r.Count
+	default:
+		return r.Count, r.Count
+	}
+}
+
+// validateCount evaluates children until the number of passing children is
+// known to be inside or outside the accepted range, skipping the rest. A child
+// that errored or timed out may or may not have passed; if the outcome depends
+// on it, the rule errors or times out rather than pass or fail
+func (r *Rule[T]) validateCount(ctx context.Context, value T) *Result {
+	min, max := r.countBounds()
+	children := make([]*Result, len(r.Children))
+	passed, errored, timedOut := 0, 0, 0
+
+	i := 0
+	for ; i < len(r.Children); i++ {
+		possible := passed + errored + timedOut + len(r.Children) - i
+		if (passed >= min && possible <= max) || passed > max || possible < min {
+			break
+		}
+
+		children[i] = r.Children[i].validateRecursive(ctx, value)
+		switch children[i].Status {
+		case StatusPass:
+			passed++
+		case StatusError:
+			errored++
+		case StatusTimeout:
+			timedOut++
+		}
+	}
+
+	// Mark remaining children as skipped
+	for j := i; j < len(r.Children); j++ {
+		children[j] = skipped(r.Children[j])
+	}
+
+	result := &Result{
+		Status:   StatusPass,
+		Label:    r.Label,
+		Kind:     r.Kind,
+		Params:   Params{"passed": passed},
+		Children: children,
+	}
+	possible := passed + errored + timedOut + len(r.Children) - i
+	switch {
+	case passed > max || possible < min:
+		failure := r.countFailure(passed)
+		result.Status = StatusFail
+		result.Message = failure.Message
+		result.Code = failure.Code
+		result.Params = failure.Params
+	case passed < min || possible > max:
+		// A broken child must not decide the count
+		if errored > 0 {
+			result.Status = StatusError
+			result.Message = fmt.Sprintf("count undecided (%d rules errored)", errored)
+			result.Code = "count.child_errored"
+			result.Params["errored"] = errored
+		} else {
+			result.Status = StatusTimeout
+			result.Message = fmt.Sprintf("count undecided (%d rules timed out)", timedOut)
+			result.Code = "count.child_timed_out"
+			result.Params["timed_out"] = timedOut
+		}
+	}
+	return result
+}
+
+// countFailure describes a failed count
+func (r *Rule[T]) countFailure(passed int) *Failure {
+	switch r.Kind {
+	case KindOneOf:
+		if passed == 0 {
+			return NewFailure("count.none_passed", "none of the rules passed", Params{"passed": passed})
+		}
+		return NewFailure("count.multiple_passed", "multiple rules passed (expected exactly one)", Params{"passed": passed})
+	case KindAtLeast:
+		return NewFailure("count.too_few",
+			fmt.Sprintf("only %d rules passed (expected at least %d)", passed, r.Count),
+			Params{"passed": passed, "min": r.Count})
+	case KindAtMost:
+		return NewFailure("count.too_many",
+			fmt.Sprintf("%d rules passed (expected at most %d)", passed, r.Count),
+			Params{"passed": passed, "max": r.Count})
+	default:
+		return NewFailure("count.not_exactly",
+			fmt.Sprintf("%d rules passed (expected exactly %d)", passed, r.Count),
+			Params{"passed": passed, "expected": r.Count})
+	}
+}
@@ -0,0 +1,87 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+)
+
+// At creates a rule that validates a whole parent value S, such as a struct
+// or a decoded JSON object, but reports the result at the path of the field
+// name. It is the building block of cross-field rules
+func At[S any](name string, rule *Rule[S]) *Rule[S] {
+	return Field(name, func(v S) S { return v }, rule)
+}
+
+// Equal creates a rule reported at field name that fails unless the value
+// read by get equals the value of field other read by getOther
+func Equal[S any, F comparable](name string, get func(S) F, other string, getOther func(S) F) *Rule[S] {
+	return At(name, Test("equal", func(ctx context.Context, v S) error {
+		if get(v) != getOther(v) {
+			return NewFailure("field.not_equal",
+				fmt.Sprintf("value does not equal %s", other),
+				Params{"field": other})
+		}
+		return nil
+	}))
+}
+
+// Before creates a rule reported at field name that fails unless the value
+// read by get orders before the value of field other in the order of compare
+func Before[S, F any](name string, get func(S) F, other string, getOther func(S) F, compare func(a, b F) int) *Rule[S] {
+	return At(name, Test("before", func(ctx context.Context, v S) error {
+		if compare(get(v), getOther(v)) >= 0 {
+			return NewFailure("field.not_before",
+				fmt.Sprintf("value is not before %s", other),
+				Params{"field": other})
+		}
+		return nil
+	}))
+}
+
+// After creates a rule reported at field name that fails unless the value
+// read by get orders after the value of field other in the order of compare
+func After[S, F any](name string, get func(S) F, other string, getOther func(S) F, compare func(a, b F) int) *Rule[S] {
+	return At(name, Test("after", func(ctx context.Context, v S) error {
+		if compare(get(v), getOther(v)) <= 0 {
+			return NewFailure("field.not_after",
+				fmt.Sprintf("value is not after %s", other),
+				Params{"field": other})
+		}
+		return nil
+	}))
+}
+
+// RequiredWith creates a rule reported at field name that fails if field
+// other is set but name is not
+func RequiredWith[S any](name string, isSet func(S) bool, other string, otherSet func(S) bool) *Rule[S] {
+	return At(name, Test("required-with", func(ctx context.Context, v S) error {
+		if otherSet(v) && !isSet(v) {
+			return NewFailure("field.required_with",
+				fmt.Sprintf("value is required when %s is set", other),
+				Params{"field": other})
+		}
+		return nil
+	}))
+}
+
+// EitherRequired creates a rule reported at field name that fails if neither
+// name nor other is set
+func EitherRequired[S any](name string, isSet func(S) bool, other string, otherSet func(S) bool) *Rule[S] {
+	return At(name, Test("either-required", func(ctx context.Context, v S) error {
+		if !isSet(v) && !otherSet(v) {
+			return NewFailure("field.either_required",
+				fmt.Sprintf("value is required unless %s is set", other),
+				Params{"field": other})
+		}
+		return nil
+	}))
+}
+
+// NotZero returns a predicate for cross-field rules that reports whether the
+// value read by get is set, i.e. not the zero value of F
+func NotZero[S any, F comparable](get func(S) F) func(S) bool {
+	return func(v S) bool {
+		var zero F
+		return get(v) != zero
+	}
+}
@@ -0,0 +1,84 @@
+package gook
+
+import (
+	"cmp"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type testSignup struct {
+	Password string
+	Confirm  string
+	Start    time.Time
+	End      time.Time
+	Phone    string
+	Email    string
+}
+
+func TestCrossFieldRules(t *testing.T) {
+	ctx := context.Background()
+	password := func(s testSignup) string { return s.Password }
+	confirm := func(s testSignup) string { return s.Confirm }
+	start := func(s testSignup) time.Time { return s.Start }
+	end := func(s testSignup) time.Time { return s.End }
+	phone := NotZero(func(s testSignup) string { return s.Phone })
+	email := NotZero(func(s testSignup) string { return s.Email })
+
+	rule := Object("signup",
+		Field("password", password, StringLength(8, 64)),
+		Equal("password_confirm", confirm, "password", password),
+		After("end", end, "start", start, time.Time.Compare),
+		EitherRequired("phone", phone, "email", email),
+	)
+
+	now := time.Now()
+	valid := testSignup{Password: "correct horse", Confirm: "correct horse", Start: now, End: now.Add(time.Hour), Email: "a@x"}
+	if result, ok := rule.Validate(ctx, valid); !ok {
+		t.Errorf("Expected valid signup to pass, got: %s", result.Format())
+	}
+
+	invalid := testSignup{Password: "correct horse", Confirm: "correct", Start: now, End: now}
+	result, _ := rule.Validate(ctx, invalid, CollectAll())
+	want := map[string][]string{
+		"/password_confirm": {"value does not equal password"},
+		"/end":              {"value is not after start"},
+		"/phone":            {"value is required unless email is set"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if code := result.Children[1].Children[0].Code; code != "field.not_equal" {
+		t.Errorf("Expected field.not_equal, got %q", code)
+	}
+}
+
+func TestCrossFieldJSON(t *testing.T) {
+	ctx := context.Background()
+	prop := func(name string) func(any) any {
+		return func(v any) any { return v.(map[string]any)[name] }
+	}
+	isSet := func(name string) func(any) bool {
+		return func(v any) bool { return prop(name)(v) != nil }
+	}
+	rule := All(
+		JSONObject("range", OptionalProp("min", JSONNumber(Test("any", func(ctx context.Context, n int) error { return nil })))),
+		RequiredWith("max", isSet("max"), "min", isSet("min")),
+		Before("min", prop("min"), "max", prop("max"), func(a, b any) int {
+			if a == nil || b == nil {
+				return -1
+			}
+			return cmp.Compare(a.(float64), b.(float64))
+		}),
+	)
+
+	result, _ := rule.Validate(ctx, decodeJSON(t, `{"min": 3}`))
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/max": {"value is required when min is set"}}) {
+		t.Errorf("Expected failure at /max, got %v", got)
+	}
+	result, _ = rule.Validate(ctx, decodeJSON(t, `{"min": 5, "max": 2}`))
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/min": {"value is not before max"}}) {
+		t.Errorf("Expected failure at /min, got %v", got)
+	}
+}
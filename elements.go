@@ -0,0 +1,61 @@
+package gook
+
+import (
+	"context"
+	"iter"
+)
+
+// Elements creates a rule that validates every element yielded by items
+// against rule. Each element gets an item node labeled with its key and
+// recording the key as a path segment; an empty key validates the element at
+// the path of the collection itself. Like All, it stops at the first failing
+// element and reports the rest as skipped, unless the CollectAll option is set
+func Elements[T, E any](label string, items func(T) iter.Seq2[string, E], rule *Rule[E]) *Rule[T] {
+	return &Rule[T]{
+		Label: label,
+		Kind:  KindEach,
+		eval: func(ctx context.Context, value T) *Result {
+			collect := optionsFrom(ctx).collectAll
+			children := []*Result{}
+			failed := false
+
+			for key, elem := range items(value) {
+				itemCtx, item := newItem(ctx, key)
+				children = append(children, item)
+
+				// Short-circuit on first failure
+				if failed && !collect {
+					continue
+				}
+				childResult := rule.validateRecursive(itemCtx, elem)
+				item.Status = childResult.Status
+				item.Severity = childResult.Severity
+				item.Children = []*Result{childResult}
+				failed = failed || childResult.failed()
+			}
+
+			status, severity := allStatus(children)
+			return &Result{
+				Status:   status,
+				Severity: severity,
+				Children: children,
+			}
+		},
+	}
+}
+
+// newItem returns a skipped item node for the element at key and the context
+// to validate the element in
+func newItem(ctx context.Context, key string) (context.Context, *Result) {
+	item := &Result{
+		Status: StatusSkip,
+		Label:  key,
+		Kind:   KindItem,
+	}
+	if key == "" {
+		item.Label = "value"
+		return ctx, item
+	}
+	ctx, item.Path = descend(ctx, key)
+	return ctx, item
+}
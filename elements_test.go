@@ -0,0 +1,53 @@
+package gook
+
+import (
+	"context"
+	"iter"
+	"strconv"
+	"testing"
+)
+
+func TestElements(t *testing.T) {
+	ctx := context.Background()
+
+	byIndex := func(values []string) iter.Seq2[string, string] {
+		return func(yield func(string, string) bool) {
+			for i, v := range values {
+				if !yield(strconv.Itoa(i), v) {
+					return
+				}
+			}
+		}
+	}
+	rule := Field("emails", func(v []string) []string { return v },
+		Elements("emails", byIndex, StringContains("@")))
+
+	// Test first failure skips the remaining elements
+	result, ok := rule.Validate(ctx, []string{"a@x", "b", "c", "d@x"})
+	if ok {
+		t.Error("Expected Elements to fail")
+	}
+	each := result.Children[0]
+	if each.Kind != KindEach || len(each.Children) != 4 {
+		t.Fatalf("Expected each node with 4 items, got: %s", result.Format())
+	}
+	item := each.Children[1]
+	if item.Kind != KindItem || item.Label != "1" || item.Path != "/emails/1" || item.Status != StatusFail {
+		t.Errorf("Expected failing item at /emails/1, got %v %s %s %v", item.Kind, item.Label, item.Path, item.Status)
+	}
+	if each.Children[2].Status != StatusSkip || each.Children[2].Path != "/emails/2" {
+		t.Errorf("Expected later items to be skipped, got: %s", result.Format())
+	}
+
+	// Test CollectAll reports every failing element
+	result, _ = rule.Validate(ctx, []string{"a@x", "b", "c", "d@x"}, CollectAll())
+	flat := result.Flatten()
+	if len(flat) != 2 || flat["/emails/1"] == nil || flat["/emails/2"] == nil {
+		t.Errorf("Expected failures at /emails/1 and /emails/2, got %v", flat)
+	}
+
+	// Test empty collections pass
+	if _, ok := rule.Validate(ctx, nil); !ok {
+		t.Error("Expected empty collection to pass")
+	}
+}
@@ -0,0 +1,44 @@
+// Code generated by gook-gen; DO NOT EDIT.
+
+package examples
+
+import (
+	"github.com/johan-st/gook"
+	"github.com/johan-st/gook/rules"
+	"github.com/johan-st/gook/tags"
+)
+
+var (
+	genSignupRule  = &gook.Rule[Signup]{}
+	genAddressRule = &gook.Rule[Address]{}
+)
+
+func init() {
+	*genSignupRule = *gook.Object[Signup]("Signup",
+		gook.Field("email", func(v Signup) string { return v.Email }, gook.All(
+			tags.Required[string](),
+			rules.Email(),
+			gook.StringLength(3, 254),
+		)),
+		gook.Field("age", func(v Signup) int { return v.Age }, tags.Min[int](18)),
+		gook.Field("tags", func(v Signup) []string { return v.Tags }, gook.All(
+			tags.Len[[]string](0, 3),
+			gook.Elements("elements", tags.Indexed[[]string], gook.StringLength(1, 16)),
+		)),
+		gook.Field("home", func(v Signup) Address { return v.Home }, AddressRule()),
+	)
+	*genAddressRule = *gook.Object[Address]("Address",
+		gook.Field("city", func(v Address) string { return v.City }, tags.Required[string]()),
+		gook.Field("zip", func(v Address) string { return v.Zip }, gook.StringLength(5, 5)),
+	)
+}
+
+// SignupRule returns the rule for Signup built from its gook struct tags
+func SignupRule() *gook.Rule[Signup] {
+	return genSignupRule
+}
+
+// AddressRule returns the rule for Address built from its gook struct tags
+func AddressRule() *gook.Rule[Address] {
+	return genAddressRule
+}
@@ -0,0 +1,41 @@
+package examples
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	ok "github.com/johan-st/gook"
+)
+
+//go:generate go run ../cmd/gook-gen -type=Signup
+
+type Signup struct {
+	Email string   `json:"email" gook:"required,email,len=3..254"`
+	Age   int      `json:"age" gook:"min=18"`
+	Tags  []string `json:"tags" gook:"len=..3,dive,len=1..16"`
+	Home  Address  `json:"home"`
+}
+
+type Address struct {
+	City string `json:"city" gook:"required"`
+	Zip  string `json:"zip" gook:"len=5"`
+}
+
+// GeneratedSignup validates a sign-up with rules generated from its struct tags
+func GeneratedSignup(email string) {
+	signup := Signup{
+		Email: email,
+		Age:   17,
+		Tags:  []string{"go", ""},
+		Home:  Address{City: "Lund", Zip: "2210"},
+	}
+	res, valid := SignupRule().Validate(context.Background(), signup, ok.CollectAll())
+	fmt.Printf("valid: %v\n", valid)
+	fmt.Println(res.Format())
+	failures := res.Flatten()
+	for _, path := range slices.Sorted(maps.Keys(failures)) {
+		fmt.Printf("%s: %v\n", path, failures[path])
+	}
+}
@@ -0,0 +1,38 @@
+package gook
+
+import "errors"
+
+// Params holds the typed parameters of a failure, e.g. {"min": 3, "got": 1}
+type Params map[string]any
+
+// Failure is a rule failure with a stable code and typed parameters
+// Return it from a TestFn so clients can render their own messages from the
+// code and params instead of parsing the English message
+type Failure struct {
+	Code    string // stable identifier such as "string.too_short"
+	Message string // English fallback message
+	Params  Params
+}
+
+// NewFailure creates a failure with the given code, message and params
+func NewFailure(code, message string, params Params) *Failure {
+	return &Failure{
+		Code:    code,
+		Message: message,
+		Params:  params,
+	}
+}
+
+// Error returns the failure message
+func (f *Failure) Error() string {
+	return f.Message
+}
+
+// setFailure copies the code and params of a *Failure in err to the result
+func (r *Result) setFailure(err error) {
+	var failure *Failure
+	if errors.As(err, &failure) {
+		r.Code = failure.Code
+		r.Params = failure.Params
+	}
+}
@@ -0,0 +1,37 @@
+package gook
+
+import "context"
+
+// Field creates a rule that validates the field of a struct S read by get
+// The result is labeled with the field name and records it as a path segment
+func Field[S, F any](name string, get func(S) F, rule *Rule[F]) *Rule[S] {
+	return &Rule[S]{
+		Label: name,
+		Kind:  KindField,
+		eval: func(ctx context.Context, value S) *Result {
+			ctx, path := descend(ctx, name)
+			result := wrap(rule.validateRecursive(ctx, get(value)))
+			result.Path = path
+			return result
+		},
+	}
+}
+
+// Object groups the field rules of a struct S under a label
+// Fields are combined like All; use the CollectAll option to report every field
+func Object[S any](label string, fields ...*Rule[S]) *Rule[S] {
+	return &Rule[S]{
+		Label:    label,
+		Kind:     KindAll,
+		Children: fields,
+	}
+}
+
+// wrap returns a result with the outcome of its single child
+func wrap(child *Result) *Result {
+	return &Result{
+		Status:   child.Status,
+		Severity: child.Severity,
+		Children: []*Result{child},
+	}
+}
@@ -0,0 +1,79 @@
+package gook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type testAddress struct {
+	City string
+	Zip  string
+}
+
+type testUser struct {
+	Name    string
+	Age     int
+	Address testAddress
+}
+
+func TestField(t *testing.T) {
+	ctx := context.Background()
+
+	adult := Test("adult", func(ctx context.Context, n int) error {
+		if n < 18 {
+			return NewFailure("number.too_small", "must be at least 18", Params{"min": 18, "got": n})
+		}
+		return nil
+	})
+	rule := Object("user",
+		Field("name", func(u testUser) string { return u.Name }, StringLength(2, 50)),
+		Field("age", func(u testUser) int { return u.Age }, adult),
+		Field("address", func(u testUser) testAddress { return u.Address }, Object("address",
+			Field("city", func(a testAddress) string { return a.City }, StringLength(1, 100)),
+			Field("zip", func(a testAddress) string { return a.Zip }, StringLength(5, 5)),
+		)),
+	)
+
+	// Test valid struct
+	valid := testUser{Name: "Ada", Age: 36, Address: testAddress{City: "Lund", Zip: "22100"}}
+	result, ok := rule.Validate(ctx, valid)
+	if !ok {
+		t.Errorf("Expected valid user to pass, got: %s", result.Format())
+	}
+	name := result.Children[0]
+	if name.Kind != KindField || name.Label != "name" || name.Path != "/name" {
+		t.Errorf("Expected field node for name, got %s %s %s", name.Kind, name.Label, name.Path)
+	}
+
+	// Test failures are reported at their field paths
+	invalid := testUser{Name: "A", Age: 12, Address: testAddress{City: "Lund", Zip: "221"}}
+	result, ok = rule.Validate(ctx, invalid, CollectAll())
+	if ok {
+		t.Error("Expected invalid user to fail")
+	}
+	want := map[string][]string{
+		"/name":        {"string too short (min: 2, got: 1)"},
+		"/age":         {"must be at least 18"},
+		"/address/zip": {"string too short (min: 5, got: 3)"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	zip := result.Children[2].Children[0].Children[1]
+	if zip.Path != "/address/zip" {
+		t.Errorf("Expected nested path '/address/zip', got '%s'", zip.Path)
+	}
+
+	// Test the field rule sees its path in the context
+	var seen string
+	pathRule := Field("city", func(a testAddress) string { return a.City },
+		Test("record", func(ctx context.Context, s string) error {
+			seen = PathFrom(ctx)
+			return nil
+		}))
+	pathRule.Validate(ctx, testAddress{})
+	if seen != "/city" {
+		t.Errorf("Expected field rule to see '/city', got '%s'", seen)
+	}
+}
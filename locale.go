@@ -0,0 +1,160 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Message is a translated message template
+// {name} placeholders are replaced by the failure param of that name. When
+// Count names a param, One is used if that param is 1 and Other otherwise
+type Message struct {
+	One   string
+	Other string
+	Count string
+}
+
+// Catalog holds translated messages per locale, keyed by failure code or, for
+// rules without a code, by rule label
+// A catalog is safe for concurrent use once it is no longer being modified
+type Catalog struct {
+	messages map[string]map[string]Message
+}
+
+// NewCatalog creates an empty message catalog
+func NewCatalog() *Catalog {
+	return &Catalog{messages: make(map[string]map[string]Message)}
+}
+
+// Set adds a message for key in locale
+func (c *Catalog) Set(locale, key, text string) *Catalog {
+	return c.SetMessage(locale, key, Message{Other: text})
+}
+
+// SetPlural adds a message for key in locale with a singular and plural form
+// selected by the param named count
+func (c *Catalog) SetPlural(locale, key, count, one, other string) *Catalog {
+	return c.SetMessage(locale, key, Message{One: one, Other: other, Count: count})
+}
+
+// SetMessage adds a message for key in locale
+func (c *Catalog) SetMessage(locale, key string, msg Message) *Catalog {
+	locale = normalizeLocale(locale)
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]Message)
+	}
+	c.messages[locale][key] = msg
+	return c
+}
+
+// Lookup returns the message for key in locale, falling back from a regional
+// locale such as "sv-SE" to its language "sv"
+func (c *Catalog) Lookup(locale, key string) (Message, bool) {
+	locale = normalizeLocale(locale)
+	for locale != "" {
+		if msg, ok := c.messages[locale][key]; ok {
+			return msg, true
+		}
+		i := strings.LastIndex(locale, "-")
+		if i < 0 {
+			break
+		}
+		locale = locale[:i]
+	}
+	return Message{}, false
+}
+
+// Translate returns the message of a single result in locale, or the result's
+// own message if the catalog has no translation for it
+func (c *Catalog) Translate(result *Result, locale string) string {
+	if result.Message == "" {
+		return ""
+	}
+	for _, key := range []string{result.Code, result.Label} {
+		if key == "" {
+			continue
+		}
+		if msg, ok := c.Lookup(locale, key); ok {
+			return msg.render(result.Params)
+		}
+	}
+	return result.Message
+}
+
+// Localize rewrites the messages of the result tree in locale
+func (c *Catalog) Localize(result *Result, locale string) *Result {
+	result.Message = c.Translate(result, locale)
+	for _, child := range result.Children {
+		c.Localize(child, locale)
+	}
+	return result
+}
+
+// render selects the plural form and interpolates the params
+func (m Message) render(params Params) string {
+	text := m.Other
+	if m.Count != "" && m.One != "" {
+		if n, ok := asInt(params[m.Count]); ok && n == 1 {
+			text = m.One
+		}
+	}
+
+	if len(params) == 0 {
+		return text
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// asInt converts integer params to int
+func asInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int8:
+		return int(n), true
+	case int16:
+		return int(n), true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case uint:
+		return int(n), true
+	case uint8:
+		return int(n), true
+	case uint16:
+		return int(n), true
+	case uint32:
+		return int(n), true
+	case uint64:
+		return int(n), true
+	case float64:
+		if n == float64(int(n)) {
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context requesting messages in locale, e.g. "sv" or "de-AT"
+// Validate localizes results with the catalog given through the Localize option
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFrom returns the locale requested by ctx, or "" if there is none
+func LocaleFrom(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
@@ -0,0 +1,62 @@
+package gook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCatalogLocalize(t *testing.T) {
+	catalog := NewCatalog().
+		Set("sv", "string.too_short", "minst {min} tecken krävs (fick {got})").
+		SetPlural("en", "string.too_short", "min", "at least {min} character required", "at least {min} characters required").
+		Set("de", "string.too_short", "mindestens {min} Zeichen erforderlich").
+		Set("sv", "no-admin", "namnet får inte vara admin")
+
+	rule := All(
+		StringLength(3, 10),
+		Test("no-admin", func(ctx context.Context, s string) error {
+			if s == "admin" {
+				return errors.New("name must not be admin")
+			}
+			return nil
+		}),
+	)
+
+	// Test locale from the context with regional fallback
+	ctx := WithLocale(context.Background(), "sv-SE")
+	result, _ := rule.Validate(ctx, "a", Localize(catalog))
+	if msg := result.Children[0].Message; msg != "minst 3 tecken krävs (fick 1)" {
+		t.Errorf("Expected Swedish message, got '%s'", msg)
+	}
+
+	// Test messages keyed by label
+	result, _ = rule.Validate(ctx, "admin", Localize(catalog))
+	if msg := result.Children[1].Message; msg != "namnet får inte vara admin" {
+		t.Errorf("Expected Swedish label message, got '%s'", msg)
+	}
+	if !strings.Contains(result.Format(), "namnet får inte vara admin") {
+		t.Errorf("Expected Format to show the translation, got: %s", result.Format())
+	}
+
+	// Test plural forms
+	result, _ = StringLength(1, 10).Validate(context.Background(), "", Localize(catalog))
+	if result.Message != "string too short (min: 1, got: 0)" {
+		t.Errorf("Expected untranslated message without locale, got '%s'", result.Message)
+	}
+	catalog.Localize(result, "en")
+	if result.Message != "at least 1 character required" {
+		t.Errorf("Expected singular form, got '%s'", result.Message)
+	}
+	result, _ = StringLength(3, 10).Validate(WithLocale(context.Background(), "en"), "", Localize(catalog))
+	if result.Message != "at least 3 characters required" {
+		t.Errorf("Expected plural form, got '%s'", result.Message)
+	}
+
+	// Test missing translations keep the original message
+	result, _ = StringLength(3, 10).Validate(WithLocale(context.Background(), "fr"), "", Localize(catalog))
+	if result.Message != "string too short (min: 3, got: 0)" {
+		t.Errorf("Expected original message, got '%s'", result.Message)
+	}
+}
@@ -0,0 +1,139 @@
+package gook
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Keys creates a rule that validates every key of a map against rule
+// Keys are visited in sorted order and each is recorded as a path segment,
+// so a failing key is reported at its own path
+func Keys[K cmp.Ordered, V any](rule *Rule[K]) *Rule[map[K]V] {
+	return Elements("keys", func(m map[K]V) iter.Seq2[string, K] {
+		return func(yield func(string, K) bool) {
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				if !yield(fmt.Sprint(k), k) {
+					return
+				}
+			}
+		}
+	}, rule)
+}
+
+// Values creates a rule that validates every value of a map against rule
+// Values are visited in sorted key order, each at the path of its key
+func Values[K cmp.Ordered, V any](rule *Rule[V]) *Rule[map[K]V] {
+	return Elements("values", sortedValues[K, V], rule)
+}
+
+// Entries creates a rule that validates the key and value of every map entry
+// Entries are visited in sorted key order, each at the path of its key. Like
+// All, it stops at the first failing entry unless the CollectAll option is set
+func Entries[K cmp.Ordered, V any](key *Rule[K], value *Rule[V]) *Rule[map[K]V] {
+	return &Rule[map[K]V]{
+		Label: "entries",
+		Kind:  KindEach,
+		eval: func(ctx context.Context, m map[K]V) *Result {
+			collect := optionsFrom(ctx).collectAll
+			children := []*Result{}
+			failed := false
+
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				itemCtx, item := newItem(ctx, fmt.Sprint(k))
+				children = append(children, item)
+
+				// Short-circuit on first failure
+				if failed && !collect {
+					continue
+				}
+				keyResult := key.validateRecursive(itemCtx, k)
+				valueResult := skipped(value)
+				if !keyResult.failed() || collect {
+					valueResult = value.validateRecursive(itemCtx, m[k])
+				}
+				item.Children = []*Result{keyResult, valueResult}
+				item.Status, item.Severity = allStatus(item.Children)
+				failed = failed || item.failed()
+			}
+
+			status, severity := allStatus(children)
+			return &Result{
+				Status:   status,
+				Severity: severity,
+				Children: children,
+			}
+		},
+	}
+}
+
+// RequiredKeys creates a rule that fails for each of keys missing from a map
+// Missing keys are reported as item nodes at their own path
+func RequiredKeys[K cmp.Ordered, V any](keys ...K) *Rule[map[K]V] {
+	return &Rule[map[K]V]{
+		Label: "required-keys",
+		Kind:  KindEach,
+		eval: func(ctx context.Context, m map[K]V) *Result {
+			children := []*Result{}
+			for _, k := range slices.Sorted(slices.Values(keys)) {
+				if _, ok := m[k]; ok {
+					continue
+				}
+				_, item := newItem(ctx, fmt.Sprint(k))
+				item.Status = StatusFail
+				item.Message = fmt.Sprintf("missing required key %v", k)
+				item.Code = "map.missing_key"
+				item.Params = Params{"key": k}
+				children = append(children, item)
+			}
+			return reported(children)
+		},
+	}
+}
+
+// AllowedKeys creates a rule that fails for each key of a map not in keys
+// Unknown keys are reported as item nodes at their own path
+func AllowedKeys[K cmp.Ordered, V any](keys ...K) *Rule[map[K]V] {
+	return &Rule[map[K]V]{
+		Label: "allowed-keys",
+		Kind:  KindEach,
+		eval: func(ctx context.Context, m map[K]V) *Result {
+			children := []*Result{}
+			for _, k := range slices.Sorted(maps.Keys(m)) {
+				if slices.Contains(keys, k) {
+					continue
+				}
+				_, item := newItem(ctx, fmt.Sprint(k))
+				item.Status = StatusFail
+				item.Message = fmt.Sprintf("unknown key %v", k)
+				item.Code = "map.unknown_key"
+				item.Params = Params{"key": k}
+				children = append(children, item)
+			}
+			return reported(children)
+		},
+	}
+}
+
+// reported fails if any item node was reported
+func reported(children []*Result) *Result {
+	status := StatusPass
+	if len(children) > 0 {
+		status = StatusFail
+	}
+	return &Result{Status: status, Children: children}
+}
+
+// sortedValues yields the values of a map keyed by key, in sorted key order
+func sortedValues[K cmp.Ordered, V any](m map[K]V) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for _, k := range slices.Sorted(maps.Keys(m)) {
+			if !yield(fmt.Sprint(k), m[k]) {
+				return
+			}
+		}
+	}
+}
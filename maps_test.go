@@ -0,0 +1,89 @@
+package gook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMapKeysAndValues(t *testing.T) {
+	ctx := context.Background()
+	labels := map[string]string{"app": "web", "Team": "core", "tier": ""}
+
+	// Test keys are visited in sorted order
+	rule := Keys[string, string](Test("lowercase", func(ctx context.Context, key string) error {
+		if key[0] < 'a' {
+			return NewFailure("key.not_lowercase", "key is not lowercase", nil)
+		}
+		return nil
+	}))
+	result, _ := rule.Validate(ctx, labels, CollectAll())
+	var order []string
+	for _, item := range result.Children {
+		order = append(order, item.Label)
+	}
+	if want := []string{"Team", "app", "tier"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("Expected keys in order %v, got %v", want, order)
+	}
+	if want := map[string][]string{"/Team": {"key is not lowercase"}}; !reflect.DeepEqual(result.Flatten(), want) {
+		t.Errorf("Expected %v, got %v", want, result.Flatten())
+	}
+
+	// Test failing values are reported at their key
+	result, ok := Values[string](StringLength(1, 10)).Validate(ctx, labels)
+	if ok {
+		t.Error("Expected empty value to fail")
+	}
+	if _, found := result.Flatten()["/tier"]; !found {
+		t.Errorf("Expected failure at /tier, got %v", result.Flatten())
+	}
+}
+
+func TestMapEntries(t *testing.T) {
+	ctx := context.Background()
+	rule := Field("labels", func(m map[string]string) map[string]string { return m },
+		Entries(StringLength(1, 4), StringLength(1, 10)))
+
+	result, ok := rule.Validate(ctx, map[string]string{"app": "web", "owner": "", "zone": "much too long"}, CollectAll())
+	if ok {
+		t.Error("Expected invalid entries to fail")
+	}
+	want := map[string][]string{
+		"/labels/owner": {"string too long (max: 4, got: 5)", "string too short (min: 1, got: 0)"},
+		"/labels/zone":  {"string too long (max: 10, got: 13)"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test the value is skipped after a failing key without CollectAll
+	result, _ = rule.Validate(ctx, map[string]string{"owner": ""})
+	entry := result.Children[0].Children[0]
+	if entry.Children[1].Status != StatusSkip {
+		t.Errorf("Expected value to be skipped, got: %s", result.Format())
+	}
+}
+
+func TestRequiredAndAllowedKeys(t *testing.T) {
+	ctx := context.Background()
+	rule := All(
+		RequiredKeys[string, int]("id", "name"),
+		AllowedKeys[string, int]("id", "name", "age"),
+	)
+
+	if result, ok := rule.Validate(ctx, map[string]int{"id": 1, "name": 2}); !ok {
+		t.Errorf("Expected known keys to pass, got: %s", result.Format())
+	}
+
+	result, _ := rule.Validate(ctx, map[string]int{"id": 1, "email": 2}, CollectAll())
+	want := map[string][]string{
+		"/name":  {"missing required key name"},
+		"/email": {"unknown key email"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if code := result.Children[1].Children[0].Code; code != "map.unknown_key" {
+		t.Errorf("Expected map.unknown_key, got %q", code)
+	}
+}
@@ -0,0 +1,99 @@
+package gook
+
+import (
+	"context"
+	"time"
+)
+
+// Option configures how a single call to Rule.Validate evaluates the tree
+type Option func(*options)
+
+// options holds the evaluation settings carried through the context
+type options struct {
+	collectAll bool
+	parallel   int           // concurrency limit per combinator, 0 when sequential
+	budget     time.Duration // deadline for the whole call, applied once by Validate
+	interrupt  bool          // a rule timeout or budget is active, run TestFn in a goroutine
+	catalog    *Catalog      // translates messages to the locale of the context
+	maxDepth   int           // limit of nested Ref and Lazy evaluations, 0 for DefaultMaxDepth
+}
+
+type optionsKey struct{}
+
+// CollectAll makes All combinators evaluate every child and report every
+// failure instead of stopping at the first one
+func CollectAll() Option {
+	return func(o *options) {
+		o.collectAll = true
+	}
+}
+
+// Parallel makes All and Any combinators evaluate their children concurrently,
+// running at most limit children of each combinator at a time (no limit if < 1).
+// Once the outcome is decided the remaining siblings are cancelled through the
+// context and reported as skipped; results keep the order of Rule.Children
+func Parallel(limit int) Option {
+	return func(o *options) {
+		if limit < 1 {
+			limit = -1
+		}
+		o.parallel = limit
+	}
+}
+
+// Budget limits the total time of a Validate call to d. Rules still running
+// when it runs out are abandoned and reported as StatusTimeout
+func Budget(d time.Duration) Option {
+	return func(o *options) {
+		o.budget = d
+	}
+}
+
+// Localize makes Validate translate result messages with catalog into the
+// locale set on the context with WithLocale
+func Localize(catalog *Catalog) Option {
+	return func(o *options) {
+		o.catalog = catalog
+	}
+}
+
+// MaxDepth limits how deeply Ref and Lazy rules may nest while validating
+// recursive data. Deeper values fail instead of exhausting the stack
+func MaxDepth(depth int) Option {
+	return func(o *options) {
+		o.maxDepth = depth
+	}
+}
+
+// withOptions returns a context carrying the inherited options with opts applied
+// The returned cancel func releases the budget deadline, if any
+func withOptions(ctx context.Context, opts []Option) (context.Context, context.CancelFunc) {
+	o := optionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cancel := context.CancelFunc(func() {})
+	if o.budget > 0 {
+		ctx, cancel = context.WithTimeoutCause(ctx, o.budget, &TimeoutError{Timeout: o.budget, Budget: true})
+		o.budget = 0
+		o.interrupt = true
+	}
+	return context.WithValue(ctx, optionsKey{}, o), cancel
+}
+
+// interruptible returns a context in which TestFn calls can be abandoned
+func interruptible(ctx context.Context) context.Context {
+	o := optionsFrom(ctx)
+	if o.interrupt {
+		return ctx
+	}
+	o.interrupt = true
+	return context.WithValue(ctx, optionsKey{}, o)
+}
+
+// optionsFrom returns the options carried by ctx, or the defaults
+func optionsFrom(ctx context.Context) options {
+	o, _ := ctx.Value(optionsKey{}).(options)
+	return o
+}
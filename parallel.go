@@ -0,0 +1,90 @@
+package gook
+
+import (
+	"context"
+	"sync"
+)
+
+// validateChildrenParallel evaluates the children concurrently, at most limit at
+// a time (no limit if < 0). decisive reports whether a child result settles the
+// outcome of the combinator; the first one that does cancels its siblings, and
+// every child that has not finished by then is reported as skipped. A nil
+// decisive evaluates every child. It returns whether the outcome was decided.
+func (r *Rule[T]) validateChildrenParallel(ctx context.Context, value T, limit int, decisive func(*Result) bool) ([]*Result, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if limit < 0 || limit > len(r.Children) {
+		limit = len(r.Children)
+	}
+
+	children := make([]*Result, len(r.Children))
+	sem := make(chan struct{}, limit)
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		decided bool
+	)
+
+	for i, child := range r.Children {
+		acquired := false
+		select {
+		case sem <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+		}
+
+		// Read the cancellation before decided: a sibling sets decided before
+		// it cancels, so a cancellation caused by a decision is seen as stop
+		cancelled := ctx.Err() != nil
+		mu.Lock()
+		stop := decided
+		mu.Unlock()
+		if stop || cancelled {
+			if acquired {
+				<-sem
+			}
+			if stop {
+				break
+			}
+			// The caller's context is done; record the cancellation as usual
+			children[i] = child.validateRecursive(ctx, value)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			childResult := child.validateRecursive(ctx, value)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if decided {
+				childResult = skipped(child)
+			} else if decisive != nil && decisive(childResult) {
+				decided = true
+				cancel()
+			}
+			children[i] = childResult
+		}()
+	}
+	wg.Wait()
+
+	for i, child := range r.Children {
+		if children[i] == nil {
+			children[i] = skipped(child)
+		}
+	}
+	return children, decided
+}
+
+// skipped returns the result for a child that was not evaluated
+func skipped[T any](rule *Rule[T]) *Result {
+	return &Result{
+		Status: StatusSkip,
+		Label:  rule.Label,
+		Kind:   rule.Kind,
+	}
+}
@@ -0,0 +1,109 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Parser validates input of type In and decodes it into Out in one step
+// It pairs the transforms that convert the input with the rule the converted
+// value must pass, so a single declaration both checks and decodes
+type Parser[In, Out any] struct {
+	rule *Rule[In]
+}
+
+// NewParser creates a parser that converts input with transform and validates
+// the converted value against rule. Use Pipe to chain several transforms
+func NewParser[In, Out any](transform Transform[In, Out], rule *Rule[Out]) *Parser[In, Out] {
+	p := &Parser[In, Out]{}
+
+	// Record the value reaching rule in the slot of the current Parse call
+	final := *rule
+	final.observe = func(ctx context.Context, value Out) {
+		if slot, ok := ctx.Value(p).(*Out); ok {
+			*slot = value
+		}
+	}
+	p.rule = transform(&final)
+	return p
+}
+
+// Rule returns the rule tree of the parser, transforms included
+func (p *Parser[In, Out]) Rule() *Rule[In] {
+	return p.rule
+}
+
+// Parse validates input like Rule.Validate and returns the converted value
+// along with the result. The value is the zero value of Out unless input is valid
+func (p *Parser[In, Out]) Parse(ctx context.Context, input In, opts ...Option) (Out, *Result, bool) {
+	var out Out
+	result, ok := p.rule.Validate(context.WithValue(ctx, p, &out), input, opts...)
+	if !ok {
+		var zero Out
+		return zero, result, false
+	}
+	return out, result, true
+}
+
+// Default replaces the zero value with value
+func Default[T comparable](value T) Transform[T, T] {
+	return Step("default", func(ctx context.Context, v T) (T, error) {
+		var zero T
+		if v == zero {
+			return value, nil
+		}
+		return v, nil
+	})
+}
+
+// ParseInt converts a decimal string to an int
+func ParseInt() Transform[string, int] {
+	return Step("parse-int", func(ctx context.Context, s string) (int, error) {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, parseFailure("parse.not_int", "value is not an integer", s)
+		}
+		return n, nil
+	})
+}
+
+// ParseFloat converts a string to a float64
+func ParseFloat() Transform[string, float64] {
+	return Step("parse-float", func(ctx context.Context, s string) (float64, error) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, parseFailure("parse.not_float", "value is not a number", s)
+		}
+		return f, nil
+	})
+}
+
+// ParseBool converts a string to a bool, accepting the forms of strconv.ParseBool
+func ParseBool() Transform[string, bool] {
+	return Step("parse-bool", func(ctx context.Context, s string) (bool, error) {
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return false, parseFailure("parse.not_bool", "value is not a boolean", s)
+		}
+		return b, nil
+	})
+}
+
+// ParseTime converts a string in the given layout to a time.Time
+func ParseTime(layout string) Transform[string, time.Time] {
+	return Step("parse-time", func(ctx context.Context, s string) (time.Time, error) {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			return time.Time{}, parseFailure("parse.not_time",
+				fmt.Sprintf("value is not a time in layout %s", layout), s)
+		}
+		return t, nil
+	})
+}
+
+// parseFailure reports input that could not be converted
+func parseFailure(code, message, input string) error {
+	return NewFailure(code, message, Params{"got": input})
+}
@@ -0,0 +1,90 @@
+package gook
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParser(t *testing.T) {
+	ctx := context.Background()
+	percent := Test("percent", func(ctx context.Context, n int) error {
+		if n < 0 || n > 100 {
+			return NewFailure("number.not_percent", "number is not a percentage", nil)
+		}
+		return nil
+	})
+	parser := NewParser(Pipe(Pipe(Trim(), Default("50")), ParseInt()), percent)
+
+	tests := []struct {
+		input string
+		want  int
+		ok    bool
+		code  string
+	}{
+		{" 42 ", 42, true, ""},
+		{"   ", 50, true, ""},
+		{"120", 0, false, "number.not_percent"},
+		{"4 2", 0, false, "parse.not_int"},
+	}
+	for _, tt := range tests {
+		got, result, ok := parser.Parse(ctx, tt.input)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Parse(%q) = %d, %v; expected %d, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+		if tt.code != "" {
+			if failures := result.Flatten()[""]; len(failures) != 1 {
+				t.Errorf("Parse(%q) expected one failure, got: %s", tt.input, result.Format())
+			}
+			if _, found := findCode(result, tt.code); !found {
+				t.Errorf("Parse(%q) expected code %s, got: %s", tt.input, tt.code, result.Format())
+			}
+		}
+	}
+}
+
+func TestParserConcurrent(t *testing.T) {
+	ctx := context.Background()
+	parser := NewParser(Pipe(Lowercase(), ParseBool()), Test("any", func(ctx context.Context, b bool) error { return nil }))
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			input, want := "TRUE", true
+			if i%2 == 0 {
+				input, want = "False", false
+			}
+			if got, _, ok := parser.Parse(ctx, input); !ok || got != want {
+				t.Errorf("Parse(%q) = %v, %v; expected %v", input, got, ok, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestParseTime(t *testing.T) {
+	parser := NewParser(ParseTime(time.DateOnly), Test("any", func(ctx context.Context, t time.Time) error { return nil }))
+	got, _, ok := parser.Parse(context.Background(), "2024-02-29")
+	if !ok || !got.Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected leap day, got %v, %v", got, ok)
+	}
+	if _, result, ok := parser.Parse(context.Background(), "2023-02-29"); ok || result.Children[0].Code != "parse.not_time" {
+		t.Errorf("Expected parse.not_time, got: %s", result.Format())
+	}
+}
+
+// findCode returns the first node in the tree with the given code
+func findCode(r *Result, code string) (*Result, bool) {
+	if r.Code == code {
+		return r, true
+	}
+	for _, child := range r.Children {
+		if found, ok := findCode(child, code); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,66 @@
+package gook
+
+import (
+	"context"
+	"strings"
+)
+
+type pathKey struct{}
+
+// PathFrom returns the JSON Pointer of the value being validated in ctx, e.g.
+// "/user/emails/2", or "" for the root value
+func PathFrom(ctx context.Context) string {
+	path, _ := ctx.Value(pathKey{}).(string)
+	return path
+}
+
+// JoinPath appends segments to a JSON Pointer, escaping "~" and "/" as
+// described in RFC 6901
+func JoinPath(path string, segments ...string) string {
+	var sb strings.Builder
+	sb.WriteString(path)
+	for _, segment := range segments {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(segment))
+	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// descend returns a context for validating the sub-value at segment, and the
+// path of that sub-value to record on the descending node's result
+func descend(ctx context.Context, segment string) (context.Context, string) {
+	path := JoinPath(PathFrom(ctx), segment)
+	return context.WithValue(ctx, pathKey{}, path), path
+}
+
+// Flatten returns the messages of the failing leaves of the result tree keyed
+// by the path of the value they failed on. Nodes without a path of their own
+// report at the path of their nearest ancestor that has one, so failures of
+// the root value are keyed by ""
+func (r *Result) Flatten() map[string][]string {
+	flat := make(map[string][]string)
+	r.flatten("", flat)
+	return flat
+}
+
+func (r *Result) flatten(path string, flat map[string][]string) {
+	if !r.failed() {
+		return
+	}
+	if r.Path != "" {
+		path = r.Path
+	}
+
+	leaf := true
+	for _, child := range r.Children {
+		if child.failed() {
+			leaf = false
+			child.flatten(path, flat)
+		}
+	}
+	if leaf {
+		flat[path] = append(flat[path], r.Message)
+	}
+}
@@ -0,0 +1,88 @@
+package gook
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestJoinPath(t *testing.T) {
+	if got := JoinPath("", "user", "emails", "2"); got != "/user/emails/2" {
+		t.Errorf("Expected '/user/emails/2', got '%s'", got)
+	}
+	if got := JoinPath("/labels", "app/name", "a~b"); got != "/labels/app~1name/a~0b" {
+		t.Errorf("Expected escaped segments, got '%s'", got)
+	}
+}
+
+func TestPathFrom(t *testing.T) {
+	ctx := context.Background()
+	if PathFrom(ctx) != "" {
+		t.Error("Expected root path for a plain context")
+	}
+
+	ctx, path := descend(ctx, "user")
+	ctx, path = descend(ctx, "emails")
+	if path != "/user/emails" || PathFrom(ctx) != "/user/emails" {
+		t.Errorf("Expected '/user/emails', got '%s' and '%s'", path, PathFrom(ctx))
+	}
+}
+
+func TestResultFlatten(t *testing.T) {
+	result := &Result{
+		Status: StatusFail,
+		Label:  "user",
+		Kind:   KindAll,
+		Children: []*Result{
+			{Status: StatusFail, Label: "not-nil", Kind: KindTest, Message: "value is nil"},
+			{
+				Status: StatusFail,
+				Label:  "name",
+				Kind:   KindAll,
+				Path:   "/name",
+				Children: []*Result{
+					{Status: StatusFail, Label: "string-length", Kind: KindTest, Message: "string too short"},
+					{Status: StatusFail, Label: "string-contains", Kind: KindTest, Message: "string does not contain @"},
+				},
+			},
+			{
+				Status: StatusFail,
+				Label:  "emails",
+				Kind:   KindAll,
+				Path:   "/emails",
+				Children: []*Result{
+					{Status: StatusPass, Label: "email", Kind: KindTest, Path: "/emails/0"},
+					{Status: StatusFail, Label: "email", Kind: KindTest, Path: "/emails/1", Message: "invalid email format"},
+				},
+			},
+			{Status: StatusWarn, Label: "hint", Kind: KindTest, Path: "/hint", Message: "only a warning"},
+		},
+	}
+
+	want := map[string][]string{
+		"":          {"value is nil"},
+		"/name":     {"string too short", "string does not contain @"},
+		"/emails/1": {"invalid email format"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test paths are part of the report
+	data, err := json.Marshal(NewReport(result))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"path":"/emails/1"`) {
+		t.Errorf("Expected path in report, got %s", data)
+	}
+	report, err := ParseReport(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Result.Children[2].Children[1].Path != "/emails/1" {
+		t.Error("Expected path to be reloaded")
+	}
+}
@@ -0,0 +1,104 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxDepth is the nesting limit of Ref and Lazy rules without MaxDepth
+const DefaultMaxDepth = 100
+
+// Registry holds named rules so rules can refer to each other, or to
+// themselves, by name with Ref
+type Registry struct {
+	mu    sync.RWMutex
+	rules map[string]any
+}
+
+// NewRegistry creates an empty rule registry
+func NewRegistry() *Registry {
+	return &Registry{rules: make(map[string]any)}
+}
+
+// Register adds or replaces the rule for name in reg
+func Register[T any](reg *Registry, name string, rule *Rule[T]) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	reg.rules[name] = rule
+}
+
+func (reg *Registry) lookup(name string) (any, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	rule, ok := reg.rules[name]
+	return rule, ok
+}
+
+// Ref creates a rule that validates against the rule registered for name in
+// reg. The name is resolved when the rule is evaluated, so it may be
+// registered later, and an unknown name or a rule of another type fails
+func Ref[T any](reg *Registry, name string) *Rule[T] {
+	return &Rule[T]{
+		Label: name,
+		Kind:  KindRef,
+		eval: func(ctx context.Context, value T) *Result {
+			found, ok := reg.lookup(name)
+			if !ok {
+				return &Result{
+					Status:  StatusFail,
+					Message: fmt.Sprintf("no rule registered as %s", name),
+					Code:    "ref.unknown",
+					Params:  Params{"name": name},
+				}
+			}
+			rule, ok := found.(*Rule[T])
+			if !ok {
+				var zero T
+				return &Result{
+					Status:  StatusFail,
+					Message: fmt.Sprintf("rule %s is a %T, not a %T", name, found, rule),
+					Code:    "ref.type_mismatch",
+					Params:  Params{"name": name, "type": fmt.Sprintf("%T", zero)},
+				}
+			}
+			return validateRef(ctx, rule, value)
+		},
+	}
+}
+
+// Lazy creates a rule that validates against the rule returned by build,
+// which is called once on first evaluation. It lets a rule refer to itself
+// through a variable that is assigned after the rule is created
+func Lazy[T any](build func() *Rule[T]) *Rule[T] {
+	rule := sync.OnceValue(build)
+	return &Rule[T]{
+		Label: "lazy",
+		Kind:  KindRef,
+		eval: func(ctx context.Context, value T) *Result {
+			return validateRef(ctx, rule(), value)
+		},
+	}
+}
+
+type depthKey struct{}
+
+// validateRef evaluates a referenced rule one level deeper, failing once the
+// maximum depth is exceeded
+func validateRef[T any](ctx context.Context, rule *Rule[T], value T) *Result {
+	max := optionsFrom(ctx).maxDepth
+	if max == 0 {
+		max = DefaultMaxDepth
+	}
+	depth, _ := ctx.Value(depthKey{}).(int)
+	if depth >= max {
+		return &Result{
+			Status:  StatusFail,
+			Message: fmt.Sprintf("maximum depth exceeded (max: %d)", max),
+			Code:    "ref.max_depth",
+			Params:  Params{"max": max},
+		}
+	}
+	ctx = context.WithValue(ctx, depthKey{}, depth+1)
+	return wrap(rule.validateRecursive(ctx, value))
+}
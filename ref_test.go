@@ -0,0 +1,80 @@
+package gook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type testComment struct {
+	Body    string
+	Replies []testComment
+}
+
+func thread(depth int) testComment {
+	c := testComment{Body: "ok"}
+	for range depth {
+		c = testComment{Body: "ok", Replies: []testComment{c}}
+	}
+	return c
+}
+
+func TestRef(t *testing.T) {
+	ctx := context.Background()
+	reg := NewRegistry()
+	rule := Ref[testComment](reg, "comment")
+	Register(reg, "comment", Object("comment",
+		Field("body", func(c testComment) string { return c.Body }, StringLength(1, 10)),
+		Field("replies", func(c testComment) []testComment { return c.Replies }, Each(rule)),
+	))
+
+	tree := testComment{Body: "root", Replies: []testComment{
+		{Body: "a"},
+		{Body: "b", Replies: []testComment{{Body: ""}}},
+	}}
+	result, ok := rule.Validate(ctx, tree, CollectAll())
+	if ok {
+		t.Error("Expected empty reply to fail")
+	}
+	if result.Kind != KindRef || result.Label != "comment" {
+		t.Errorf("Expected ref node, got %v %s", result.Kind, result.Label)
+	}
+	want := map[string][]string{"/replies/1/replies/0/body": {"string too short (min: 1, got: 0)"}}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test unknown and mistyped references fail
+	if result, ok := Ref[testComment](reg, "post").Validate(ctx, tree); ok || result.Code != "ref.unknown" {
+		t.Errorf("Expected ref.unknown, got: %s", result.Format())
+	}
+	if result, ok := Ref[string](reg, "comment").Validate(ctx, "x"); ok || result.Code != "ref.type_mismatch" {
+		t.Errorf("Expected ref.type_mismatch, got: %s", result.Format())
+	}
+}
+
+func TestLazyMaxDepth(t *testing.T) {
+	ctx := context.Background()
+	var rule *Rule[testComment]
+	rule = Object("comment",
+		Field("replies", func(c testComment) []testComment { return c.Replies }, Each(Lazy(func() *Rule[testComment] {
+			return rule
+		}))),
+	)
+
+	if result, ok := rule.Validate(ctx, thread(DefaultMaxDepth)); !ok {
+		t.Errorf("Expected thread within the default depth to pass, got: %s", result.Error())
+	}
+	if _, ok := rule.Validate(ctx, thread(DefaultMaxDepth+1)); ok {
+		t.Error("Expected thread beyond the default depth to fail")
+	}
+
+	result, ok := rule.Validate(ctx, thread(3), MaxDepth(2))
+	if ok {
+		t.Error("Expected thread beyond MaxDepth to fail")
+	}
+	want := map[string][]string{"/replies/0/replies/0/replies/0": {"maximum depth exceeded (max: 2)"}}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
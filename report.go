@@ -0,0 +1,147 @@
+package gook
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ReportVersion is the version of the JSON report format written by NewReport
+//
+// Version 1 report:
+//
+//	{
+//	  "version": 1,
+//	  "ok": false,                  // Result.OK() of the root
+//	  "result": <node>
+//	}
+//
+// Each node:
+//
+//	{
+//	  "status": "FAIL",             // PASS, FAIL, SKIP, ERROR, TIMEOUT or WARN
+//	  "kind": "all",                // RuleKind.String(), e.g. test, all, any, not, as
+//	  "label": "email",
+//	  "path": "/user/emails/2",     // JSON Pointer, only on nodes that descend into a sub-value
+//	  "severity": "warning",        // only for WARN: warning or info
+//	  "message": "...",             // omitted when empty
+//	  "code": "string.too_short",   // omitted when empty
+//	  "params": {"min": 3},         // omitted when empty
+//	  "children": [<node>, ...]     // omitted when empty
+//	}
+//
+// Numeric params decode as float64. Result.Cause and Result.Panic are not
+// serialized; their messages are kept in "message".
+const ReportVersion = 1
+
+// Report is a versioned, serializable validation trace
+type Report struct {
+	Version int     `json:"version"`
+	OK      bool    `json:"ok"`
+	Result  *Result `json:"result"`
+}
+
+// NewReport creates a report of the result in the current report version
+func NewReport(result *Result) *Report {
+	return &Report{
+		Version: ReportVersion,
+		OK:      result.OK(),
+		Result:  result,
+	}
+}
+
+// ParseReport decodes a JSON report, rejecting unsupported versions
+func ParseReport(data []byte) (*Report, error) {
+	var report Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	if report.Version < 1 || report.Version > ReportVersion {
+		return nil, fmt.Errorf("unsupported report version: %d", report.Version)
+	}
+	if report.Result == nil {
+		return nil, fmt.Errorf("report has no result")
+	}
+	return &report, nil
+}
+
+// resultJSON is the wire format of a result node
+type resultJSON struct {
+	Status   string    `json:"status"`
+	Kind     string    `json:"kind"`
+	Label    string    `json:"label"`
+	Path     string    `json:"path,omitempty"`
+	Severity string    `json:"severity,omitempty"`
+	Message  string    `json:"message,omitempty"`
+	Code     string    `json:"code,omitempty"`
+	Params   Params    `json:"params,omitempty"`
+	Children []*Result `json:"children,omitempty"`
+}
+
+// MarshalJSON encodes the result tree as report nodes
+func (r *Result) MarshalJSON() ([]byte, error) {
+	node := resultJSON{
+		Status:   r.Status.String(),
+		Kind:     r.Kind.String(),
+		Label:    r.Label,
+		Path:     r.Path,
+		Message:  r.Message,
+		Code:     r.Code,
+		Params:   r.Params,
+		Children: r.Children,
+	}
+	if r.Status == StatusWarn {
+		node.Severity = r.Severity.String()
+	}
+	return json.Marshal(node)
+}
+
+// UnmarshalJSON decodes a result tree from report nodes
+func (r *Result) UnmarshalJSON(data []byte) error {
+	var node resultJSON
+	if err := json.Unmarshal(data, &node); err != nil {
+		return err
+	}
+
+	status, err := parseName(node.Status, "status", ResultStatus.String)
+	if err != nil {
+		return err
+	}
+	kind, err := parseName(node.Kind, "kind", RuleKind.String)
+	if err != nil {
+		return err
+	}
+	severity := SeverityError
+	if node.Severity != "" {
+		if severity, err = parseName(node.Severity, "severity", Severity.String); err != nil {
+			return err
+		}
+	}
+
+	*r = Result{
+		Status:   status,
+		Label:    node.Label,
+		Kind:     kind,
+		Path:     node.Path,
+		Severity: severity,
+		Message:  node.Message,
+		Code:     node.Code,
+		Params:   node.Params,
+		Children: node.Children,
+	}
+	return nil
+}
+
+// parseName finds the enum value whose String() is name
+// Values are numbered from zero and the first unnamed one marks the end
+func parseName[E ~int](name, what string, str func(E) string) (E, error) {
+	end := str(E(-1))
+	for v := E(0); str(v) != end; v++ {
+		if str(v) == name {
+			return v, nil
+		}
+	}
+	if name == end {
+		return E(-1), nil
+	}
+	return 0, fmt.Errorf("unknown %s in report: %q", what, name)
+}
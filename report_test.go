@@ -0,0 +1,64 @@
+package gook
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestReportJSON(t *testing.T) {
+	ctx := context.Background()
+
+	rule := All(
+		WithSeverity(SeverityWarning, StringContains("!")),
+		OneOf(StringIs("x"), StringLength(5, 10)),
+		StringEndsWith("b"),
+	)
+	result, _ := rule.Validate(ctx, "ab")
+
+	data, err := json.Marshal(NewReport(result))
+	if err != nil {
+		t.Fatalf("Expected report to marshal, got %v", err)
+	}
+	for _, want := range []string{
+		`"version":1`,
+		`"ok":false`,
+		`"status":"WARN"`,
+		`"severity":"warning"`,
+		`"kind":"one-of"`,
+		`"code":"string.too_short"`,
+		`"params":{"got":2,"min":5}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("Expected report to contain %s, got %s", want, data)
+		}
+	}
+
+	// Test the report reloads into an equivalent tree
+	report, err := ParseReport(data)
+	if err != nil {
+		t.Fatalf("Expected report to parse, got %v", err)
+	}
+	if report.OK || report.Result.OK() {
+		t.Error("Expected reloaded report to fail")
+	}
+	if report.Result.Format() != result.Format() {
+		t.Errorf("Expected reloaded tree to format the same\nwant:\n%s\ngot:\n%s", result.Format(), report.Result.Format())
+	}
+	if report.Result.Children[0].Severity != SeverityWarning {
+		t.Error("Expected severity to be reloaded")
+	}
+	if report.Result.Children[1].Children[1].Params["min"] != float64(5) {
+		t.Errorf("Expected params to be reloaded, got %v", report.Result.Children[1].Children[1].Params)
+	}
+
+	// Test versions and names are checked
+	if _, err := ParseReport([]byte(`{"version":99,"ok":true,"result":{}}`)); err == nil {
+		t.Error("Expected unsupported version to be rejected")
+	}
+	_, err = ParseReport([]byte(`{"version":1,"ok":true,"result":{"status":"MAYBE","kind":"test"}}`))
+	if err == nil || !strings.Contains(err.Error(), "unknown status") {
+		t.Errorf("Expected unknown status to be rejected, got %v", err)
+	}
+}
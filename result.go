@@ -11,7 +11,10 @@ type ResultStatus int
 const (
 	StatusPass ResultStatus = iota
 	StatusFail
-	StatusSkip // for short-circuited branches
+	StatusSkip    // for short-circuited branches
+	StatusError   // the rule itself is broken, e.g. a TestFn panicked
+	StatusTimeout // a rule timeout or the validation budget ran out
+	StatusWarn    // a rule with a lower severity failed, counts as OK
 )
 
 // String returns a human-readable representation of the result status
@@ -23,6 +26,12 @@ func (s ResultStatus) String() string {
 		return "FAIL"
 	case StatusSkip:
 		return "SKIP"
+	case StatusError:
+		return "ERROR"
+	case StatusTimeout:
+		return "TIMEOUT"
+	case StatusWarn:
+		return "WARN"
 	default:
 		return "UNKNOWN"
 	}
@@ -33,13 +42,59 @@ type Result struct {
 	Status   ResultStatus
 	Label    string
 	Kind     RuleKind
-	Message  string // formatted at end, not during eval
+	Path     string   // JSON Pointer of the sub-value, set on nodes that descend into one
+	Severity Severity // severity of a StatusWarn result
+	Message  string   // formatted at end, not during eval
+	Code     string   // stable failure code such as "string.too_short", if any
+	Params   Params   // typed parameters of the failure or of a count, if any
 	Children []*Result
+	Cause    error       // the original error behind a failure, if any
+	Panic    *PanicError // set when a TestFn panicked
+}
+
+// PanicError holds a panic recovered from a TestFn
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+// Error returns the panic value as a message
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
 }
 
 // OK returns true if the result represents a successful validation
+// Results with only warnings are OK
 func (r *Result) OK() bool {
-	return r.Status == StatusPass
+	return r.Status == StatusPass || r.Status == StatusWarn
+}
+
+// statusLabel returns the status as shown by renderers, telling info apart
+// from warnings
+func (r *Result) statusLabel() string {
+	if r.Status == StatusWarn && r.Severity == SeverityInfo {
+		return "INFO"
+	}
+	return r.Status.String()
+}
+
+// failed returns true if the result counts as a failure for combinators
+func (r *Result) failed() bool {
+	return r.Status == StatusFail || r.Status == StatusError || r.Status == StatusTimeout
+}
+
+// HasStatus returns true if the result or any of its descendants has the status
+// Use HasStatus(StatusError) to detect broken rules separately from bad input
+func (r *Result) HasStatus(status ResultStatus) bool {
+	if r.Status == status {
+		return true
+	}
+	for _, child := range r.Children {
+		if child.HasStatus(status) {
+			return true
+		}
+	}
+	return false
 }
 
 // Format returns a formatted string representation of the result tree
@@ -53,7 +108,7 @@ func (r *Result) formatRecursive(sb *strings.Builder, depth int) {
 	indent := strings.Repeat("  ", depth)
 
 	// Format the current node
-	status := r.Status.String()
+	status := r.statusLabel()
 	if r.Message != "" {
 		sb.WriteString(fmt.Sprintf("%s[%s] %s (%s): %s\n",
 			indent, status, r.Label, r.Kind.String(), r.Message))
@@ -70,5 +125,57 @@ func (r *Result) formatRecursive(sb *strings.Builder, depth int) {
 
 // String returns a simple string representation of the result
 func (r *Result) String() string {
-	return fmt.Sprintf("[%s] %s", r.Status.String(), r.Label)
+	return fmt.Sprintf("[%s] %s", r.statusLabel(), r.Label)
+}
+
+// Err returns nil if the result is OK, otherwise the result itself as an error
+// whose Unwrap exposes the original errors of the failing nodes, so errors.Is
+// and errors.As see every error returned by a failing rule
+func (r *Result) Err() error {
+	if r.OK() {
+		return nil
+	}
+	return r
+}
+
+// Error returns String followed by the messages of the failing leaves of the
+// result tree. As *Result is an error, fmt prints results with Error rather
+// than String, e.g. "[FAIL] signup: email: invalid email format"
+func (r *Result) Error() string {
+	var messages []string
+	r.collectFailures(&messages)
+	if len(messages) == 0 {
+		return r.String()
+	}
+	return r.String() + ": " + strings.Join(messages, "; ")
+}
+
+func (r *Result) collectFailures(messages *[]string) {
+	if !r.failed() {
+		return
+	}
+	leaf := true
+	for _, child := range r.Children {
+		if child.failed() {
+			leaf = false
+			child.collectFailures(messages)
+		}
+	}
+	if leaf {
+		*messages = append(*messages, fmt.Sprintf("%s: %s", r.Label, r.Message))
+	}
+}
+
+// Unwrap returns the cause of the result and the errors of its failing children
+func (r *Result) Unwrap() []error {
+	var errs []error
+	if r.Cause != nil {
+		errs = append(errs, r.Cause)
+	}
+	for _, child := range r.Children {
+		if child.failed() {
+			errs = append(errs, child)
+		}
+	}
+	return errs
 }
@@ -2,9 +2,10 @@ package gook
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"runtime/debug"
 	"strings"
+	"time"
 	"unicode/utf8"
 )
 
@@ -16,6 +17,20 @@ const (
 	KindAll
 	KindAny
 	KindNot
+	KindAs
+	KindOneOf
+	KindAtLeast
+	KindAtMost
+	KindExactly
+	KindField
+	KindEach
+	KindItem
+	KindSome
+	KindNone
+	KindUnion
+	KindWhen
+	KindRef
+	KindMap
 )
 
 // String returns a human-readable representation of the rule kind
@@ -29,6 +44,34 @@ func (k RuleKind) String() string {
 		return "any"
 	case KindNot:
 		return "not"
+	case KindAs:
+		return "as"
+	case KindOneOf:
+		return "one-of"
+	case KindAtLeast:
+		return "at-least"
+	case KindAtMost:
+		return "at-most"
+	case KindExactly:
+		return "exactly"
+	case KindField:
+		return "field"
+	case KindEach:
+		return "each"
+	case KindItem:
+		return "item"
+	case KindSome:
+		return "some"
+	case KindNone:
+		return "none"
+	case KindUnion:
+		return "union"
+	case KindWhen:
+		return "when"
+	case KindRef:
+		return "ref"
+	case KindMap:
+		return "map"
 	default:
 		return "unknown"
 	}
@@ -41,6 +84,14 @@ type Rule[T any] struct {
 	Kind     RuleKind
 	TestFn   func(context.Context, T) error // returns error for message
 	Children []*Rule[T]                     // only same-typed children
+	Count    int                            // required number of passing children for counting kinds
+	Timeout  time.Duration                  // limits a single evaluation, 0 for none
+	Severity Severity                       // how a failure is reported, see WithSeverity
+
+	// eval evaluates kinds whose children are rules of another type
+	eval func(context.Context, T) *Result
+	// observe sees every value the rule validates, see Parser
+	observe func(context.Context, T)
 }
 
 // Test creates a leaf test rule
@@ -53,6 +104,7 @@ func Test[T any](label string, fn func(context.Context, T) error) *Rule[T] {
 }
 
 // All creates an AND combinator that stops at first failure
+// Use the CollectAll option to evaluate every child instead
 func All[T any](rules ...*Rule[T]) *Rule[T] {
 	return &Rule[T]{
 		Label:    "all",
@@ -89,42 +141,58 @@ func NewRule(label string, rules ...*Rule[any]) *Rule[any] {
 }
 
 // As creates a type-narrowing/transformation rule from any to T
-// If the transform fails, the As rule fails
+// The result embeds the nested rule's result tree. If the transform fails, the
+// As rule fails with a transform node and the nested rule is skipped
 func As[T any](transformFn func(any) (T, error), rule *Rule[T]) *Rule[any] {
-	return Test("as", func(ctx context.Context, val any) error {
-		// Transform the value
-		transformed, err := transformFn(val)
-		if err != nil {
-			return fmt.Errorf("transform failed: %v", err)
-		}
-		
-		// Validate with the rule
-		result, valid := rule.Validate(ctx, transformed)
-		if !valid {
-			return fmt.Errorf("validation failed: %s", result.Message)
-		}
-		
-		return nil
-	})
-}
+	return &Rule[any]{
+		Label: "as",
+		Kind:  KindAs,
+		eval: func(ctx context.Context, val any) *Result {
+			transformed, err := transformFn(val)
+			if err != nil {
+				return transformFailed(err, "as.transform_failed", skipped(rule))
+			}
 
+			// The outcome of As is the outcome of the nested rule
+			return wrap(rule.validateRecursive(ctx, transformed))
+		},
+	}
+}
 
 // Validate evaluates the rule against the given value with full trace
-func (r *Rule[T]) Validate(ctx context.Context, value T) (*Result, bool) {
+// Options apply to the whole tree, including rules nested through As
+func (r *Rule[T]) Validate(ctx context.Context, value T, opts ...Option) (*Result, bool) {
+	if len(opts) > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = withOptions(ctx, opts)
+		defer cancel()
+	}
 	result := r.validateRecursive(ctx, value)
+	if len(opts) > 0 {
+		if catalog := optionsFrom(ctx).catalog; catalog != nil {
+			if locale := LocaleFrom(ctx); locale != "" {
+				catalog.Localize(result, locale)
+			}
+		}
+	}
 	return result, result.OK()
 }
 
 func (r *Rule[T]) validateRecursive(ctx context.Context, value T) *Result {
+	if r.observe != nil {
+		r.observe(ctx, value)
+	}
+	if r.Timeout > 0 {
+		return r.applySeverity(r.validateWithTimeout(ctx, value))
+	}
+	return r.applySeverity(r.evaluate(ctx, value))
+}
+
+func (r *Rule[T]) evaluate(ctx context.Context, value T) *Result {
 	// Check for context cancellation
 	select {
 	case <-ctx.Done():
-		return &Result{
-			Status:  StatusFail,
-			Label:   r.Label,
-			Kind:    r.Kind,
-			Message: "context cancelled",
-		}
+		return r.cancelledResult(ctx)
 	default:
 	}
 
@@ -137,24 +205,94 @@ func (r *Rule[T]) validateRecursive(ctx context.Context, value T) *Result {
 		return r.validateAny(ctx, value)
 	case KindNot:
 		return r.validateNot(ctx, value)
+	case KindWhen:
+		return r.validateWhen(ctx, value)
+	case KindAs, KindField, KindEach, KindSome, KindNone, KindUnion, KindRef, KindMap:
+		return r.validateNested(ctx, value)
+	case KindOneOf, KindAtLeast, KindAtMost, KindExactly:
+		return r.validateCount(ctx, value)
 	default:
 		return &Result{
 			Status:  StatusFail,
 			Label:   r.Label,
 			Kind:    r.Kind,
 			Message: fmt.Sprintf("unknown rule kind: %v", r.Kind),
+			Code:    "rule.unknown_kind",
+		}
+	}
+}
+
+// validateNested evaluates a kind whose children are rules of another type
+func (r *Rule[T]) validateNested(ctx context.Context, value T) *Result {
+	if r.eval == nil {
+		return &Result{
+			Status:  StatusFail,
+			Label:   r.Label,
+			Kind:    r.Kind,
+			Message: fmt.Sprintf("%v rule has no nested rule", r.Kind),
+			Code:    "rule.missing_nested",
 		}
 	}
+
+	result := r.runEval(ctx, value)
+	result.Label = r.Label
+	result.Kind = r.Kind
+	return result
+}
+
+// runEval calls eval, turning a panic in user code it calls, such as an As
+// transform or a Field getter, into an error result like runTest does
+func (r *Rule[T]) runEval(ctx context.Context, value T) (result *Result) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicErr := &PanicError{Value: v, Stack: debug.Stack()}
+			result = &Result{
+				Status:  StatusError,
+				Message: panicErr.Error(),
+				Code:    "rule.panic",
+				Cause:   panicErr,
+				Panic:   panicErr,
+			}
+		}
+	}()
+	return r.eval(ctx, value)
 }
 
 func (r *Rule[T]) validateTest(ctx context.Context, value T) *Result {
-	if err := r.TestFn(ctx, value); err != nil {
+	var err error
+	var panicErr *PanicError
+	if optionsFrom(ctx).interrupt {
+		err, panicErr = r.runTestInterruptible(ctx, value)
+	} else {
+		err, panicErr = r.runTest(ctx, value)
+	}
+	if err != nil && ctx.Err() != nil {
+		// Report an overrun rather than whatever the TestFn made of it
+		if result := r.cancelledResult(ctx); result.Status == StatusTimeout {
+			return result
+		}
+	}
+	if panicErr != nil {
 		return &Result{
+			Status:  StatusError,
+			Label:   r.Label,
+			Kind:    KindTest,
+			Message: panicErr.Error(),
+			Code:    "rule.panic",
+			Cause:   panicErr,
+			Panic:   panicErr,
+		}
+	}
+	if err != nil {
+		result := &Result{
 			Status:  StatusFail,
 			Label:   r.Label,
 			Kind:    KindTest,
 			Message: err.Error(),
+			Cause:   err,
 		}
+		result.setFailure(err)
+		return result
 	}
 	return &Result{
 		Status: StatusPass,
@@ -163,7 +301,25 @@ func (r *Rule[T]) validateTest(ctx context.Context, value T) *Result {
 	}
 }
 
+// runTest calls TestFn, recovering a panic into a *PanicError
+func (r *Rule[T]) runTest(ctx context.Context, value T) (err error, panicErr *PanicError) {
+	defer func() {
+		if v := recover(); v != nil {
+			panicErr = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return r.TestFn(ctx, value), nil
+}
+
 func (r *Rule[T]) validateAll(ctx context.Context, value T) *Result {
+	opts := optionsFrom(ctx)
+	if opts.parallel != 0 {
+		return r.validateAllParallel(ctx, value, opts)
+	}
+	if opts.collectAll {
+		return r.validateAllCollect(ctx, value)
+	}
+
 	children := make([]*Result, len(r.Children))
 
 	for i, child := range r.Children {
@@ -171,14 +327,10 @@ func (r *Rule[T]) validateAll(ctx context.Context, value T) *Result {
 		children[i] = childResult
 
 		// Short-circuit on first failure
-		if childResult.Status == StatusFail {
+		if childResult.failed() {
 			// Mark remaining children as skipped
 			for j := i + 1; j < len(r.Children); j++ {
-				children[j] = &Result{
-					Status: StatusSkip,
-					Label:  r.Children[j].Label,
-					Kind:   r.Children[j].Kind,
-				}
+				children[j] = skipped(r.Children[j])
 			}
 			return &Result{
 				Status:   StatusFail,
@@ -189,15 +341,64 @@ func (r *Rule[T]) validateAll(ctx context.Context, value T) *Result {
 		}
 	}
 
+	status, severity := warnings(children)
 	return &Result{
-		Status:   StatusPass,
+		Status:   status,
+		Severity: severity,
 		Label:    r.Label,
 		Kind:     KindAll,
 		Children: children,
 	}
 }
 
+// validateAllCollect evaluates every child and fails if any of them failed
+func (r *Rule[T]) validateAllCollect(ctx context.Context, value T) *Result {
+	children := make([]*Result, len(r.Children))
+	for i, child := range r.Children {
+		children[i] = child.validateRecursive(ctx, value)
+	}
+	return r.allResult(children)
+}
+
+// allResult returns the result of an All whose children were all evaluated
+func (r *Rule[T]) allResult(children []*Result) *Result {
+	status, severity := allStatus(children)
+	return &Result{
+		Status:   status,
+		Severity: severity,
+		Label:    r.Label,
+		Kind:     KindAll,
+		Children: children,
+	}
+}
+
+// validateAllParallel evaluates the children concurrently, cancelling the rest
+// at the first failure unless every child should be collected
+func (r *Rule[T]) validateAllParallel(ctx context.Context, value T, opts options) *Result {
+	var decisive func(*Result) bool
+	if !opts.collectAll {
+		decisive = (*Result).failed
+	}
+	children, _ := r.validateChildrenParallel(ctx, value, opts.parallel, decisive)
+	return r.allResult(children)
+}
+
+// allStatus returns StatusFail if any of the children failed, otherwise the
+// status and severity of their warnings
+func allStatus(children []*Result) (ResultStatus, Severity) {
+	for _, childResult := range children {
+		if childResult.failed() {
+			return StatusFail, SeverityError
+		}
+	}
+	return warnings(children)
+}
+
 func (r *Rule[T]) validateAny(ctx context.Context, value T) *Result {
+	if opts := optionsFrom(ctx); opts.parallel != 0 {
+		return r.validateAnyParallel(ctx, value, opts)
+	}
+
 	children := make([]*Result, len(r.Children))
 
 	for i, child := range r.Children {
@@ -208,11 +409,7 @@ func (r *Rule[T]) validateAny(ctx context.Context, value T) *Result {
 		if childResult.Status == StatusPass {
 			// Mark remaining children as skipped
 			for j := i + 1; j < len(r.Children); j++ {
-				children[j] = &Result{
-					Status: StatusSkip,
-					Label:  r.Children[j].Label,
-					Kind:   r.Children[j].Kind,
-				}
+				children[j] = skipped(r.Children[j])
 			}
 			return &Result{
 				Status:   StatusPass,
@@ -223,8 +420,39 @@ func (r *Rule[T]) validateAny(ctx context.Context, value T) *Result {
 		}
 	}
 
+	return r.anyUnpassedResult(children)
+}
+
+// anyUnpassedResult returns the result of an Any where no child passed
+// It warns rather than fails if one of the children warned
+func (r *Rule[T]) anyUnpassedResult(children []*Result) *Result {
+	status, severity := warnings(children)
+	if status != StatusWarn {
+		status = StatusFail
+	}
+
 	return &Result{
-		Status:   StatusFail,
+		Status:   status,
+		Severity: severity,
+		Label:    r.Label,
+		Kind:     KindAny,
+		Children: children,
+	}
+}
+
+// validateAnyParallel evaluates the children concurrently, cancelling the rest
+// at the first success
+func (r *Rule[T]) validateAnyParallel(ctx context.Context, value T, opts options) *Result {
+	children, passed := r.validateChildrenParallel(ctx, value, opts.parallel, func(res *Result) bool {
+		return res.Status == StatusPass
+	})
+
+	if !passed {
+		return r.anyUnpassedResult(children)
+	}
+
+	return &Result{
+		Status:   StatusPass,
 		Label:    r.Label,
 		Kind:     KindAny,
 		Children: children,
@@ -238,6 +466,7 @@ func (r *Rule[T]) validateNot(ctx context.Context, value T) *Result {
 			Label:   r.Label,
 			Kind:    KindNot,
 			Message: "not rule must have exactly one child",
+			Code:    "rule.invalid_not",
 		}
 	}
 
@@ -245,12 +474,24 @@ func (r *Rule[T]) validateNot(ctx context.Context, value T) *Result {
 
 	// Invert the result
 	var status ResultStatus
-	var message string
+	var message, code string
 	if childResult.Status == StatusPass {
 		status = StatusFail
 		message = "not rule failed (child passed)"
-	} else if childResult.Status == StatusFail {
+		code = "not.child_passed"
+	} else if childResult.Status == StatusFail || childResult.Status == StatusWarn {
+		// A warning is a failed check, so its negation holds
 		status = StatusPass
+	} else if childResult.Status == StatusError {
+		// A broken child must not turn into a pass
+		status = StatusError
+		message = "not rule errored (child errored)"
+		code = "not.child_errored"
+	} else if childResult.Status == StatusTimeout {
+		// A timed out child must not turn into a pass either
+		status = StatusTimeout
+		message = "not rule timed out (child timed out)"
+		code = "not.child_timed_out"
 	} else {
 		status = StatusSkip
 	}
@@ -260,39 +501,16 @@ func (r *Rule[T]) validateNot(ctx context.Context, value T) *Result {
 		Label:    r.Label,
 		Kind:     KindNot,
 		Message:  message,
+		Code:     code,
 		Children: []*Result{childResult},
 	}
 }
 
-// OneOf creates a rule that passes if exactly one of the given rules passes
-func OneOf[T any](rules ...*Rule[T]) *Rule[T] {
-	return Test("one-of", func(ctx context.Context, value T) error {
-		passCount := 0
-		var lastError error
-
-		for _, rule := range rules {
-			result, _ := rule.Validate(ctx, value)
-			if result.OK() {
-				passCount++
-			} else {
-				lastError = errors.New(result.Message)
-			}
-		}
-
-		if passCount == 0 {
-			return fmt.Errorf("none of the rules passed: %v", lastError)
-		} else if passCount > 1 {
-			return errors.New("multiple rules passed (expected exactly one)")
-		}
-		return nil
-	})
-}
-
 // NotNil creates a rule that ensures a value is not nil
 func NotNil(label string) *Rule[any] {
 	return Test(label, func(ctx context.Context, value any) error {
 		if value == nil {
-			return errors.New("value is nil")
+			return NewFailure("value.nil", "value is nil", nil)
 		}
 		return nil
 	})
@@ -306,7 +524,7 @@ func AssertBytes(v any) ([]byte, error) {
 	case string:
 		return []byte(v), nil
 	default:
-		return nil, fmt.Errorf("value is not []byte or string")
+		return nil, NewFailure("type.not_bytes", "value is not []byte or string", nil)
 	}
 }
 
@@ -314,7 +532,7 @@ func AssertBytes(v any) ([]byte, error) {
 func AssertString(v any) (string, error) {
 	s, ok := v.(string)
 	if !ok {
-		return "", fmt.Errorf("value is not a string")
+		return "", NewFailure("type.not_string", "value is not a string", nil)
 	}
 	return s, nil
 }
@@ -323,7 +541,9 @@ func AssertString(v any) (string, error) {
 func BytesMax(max int) *Rule[[]byte] {
 	return Test("bytes-max", func(ctx context.Context, value []byte) error {
 		if len(value) > max {
-			return fmt.Errorf("bytes too long (max: %d, got: %d)", max, len(value))
+			return NewFailure("bytes.too_long",
+				fmt.Sprintf("bytes too long (max: %d, got: %d)", max, len(value)),
+				Params{"max": max, "got": len(value)})
 		}
 		return nil
 	})
@@ -333,7 +553,9 @@ func BytesMax(max int) *Rule[[]byte] {
 func BytesMin(min int) *Rule[[]byte] {
 	return Test("bytes-min", func(ctx context.Context, value []byte) error {
 		if len(value) < min {
-			return fmt.Errorf("bytes too short (min: %d, got: %d)", min, len(value))
+			return NewFailure("bytes.too_short",
+				fmt.Sprintf("bytes too short (min: %d, got: %d)", min, len(value)),
+				Params{"min": min, "got": len(value)})
 		}
 		return nil
 	})
@@ -353,10 +575,10 @@ func BytesEncoding(enc Encoding) *Rule[[]byte] {
 		switch enc {
 		case EncodingUTF8:
 			if !utf8.Valid(value) {
-				return fmt.Errorf("bytes are not valid UTF-8")
+				return NewFailure("bytes.invalid_encoding", "bytes are not valid UTF-8", Params{"encoding": "utf-8"})
 			}
 		default:
-			return errors.New("unknown encoding")
+			return NewFailure("bytes.unknown_encoding", "unknown encoding", Params{"encoding": int(enc)})
 		}
 
 		return nil
@@ -368,10 +590,14 @@ func StringLength(min, max int) *Rule[string] {
 	return Test("string-length", func(ctx context.Context, value string) error {
 		length := len(value)
 		if length < min {
-			return fmt.Errorf("string too short (min: %d, got: %d)", min, length)
+			return NewFailure("string.too_short",
+				fmt.Sprintf("string too short (min: %d, got: %d)", min, length),
+				Params{"min": min, "got": length})
 		}
 		if length > max {
-			return fmt.Errorf("string too long (max: %d, got: %d)", max, length)
+			return NewFailure("string.too_long",
+				fmt.Sprintf("string too long (max: %d, got: %d)", max, length),
+				Params{"max": max, "got": length})
 		}
 		return nil
 	})
@@ -381,7 +607,9 @@ func StringLength(min, max int) *Rule[string] {
 func StringContains(substring string) *Rule[string] {
 	return Test("string-contains", func(ctx context.Context, value string) error {
 		if !strings.Contains(value, substring) {
-			return fmt.Errorf("string does not contain %s", substring)
+			return NewFailure("string.missing_substring",
+				fmt.Sprintf("string does not contain %s", substring),
+				Params{"substring": substring})
 		}
 		return nil
 	})
@@ -391,7 +619,9 @@ func StringContains(substring string) *Rule[string] {
 func StringEndsWith(suffix string) *Rule[string] {
 	return Test("string-ends-with", func(ctx context.Context, value string) error {
 		if !strings.HasSuffix(value, suffix) {
-			return fmt.Errorf("string does not end with %s", suffix)
+			return NewFailure("string.missing_suffix",
+				fmt.Sprintf("string does not end with %s", suffix),
+				Params{"suffix": suffix})
 		}
 		return nil
 	})
@@ -401,7 +631,9 @@ func StringEndsWith(suffix string) *Rule[string] {
 func StringIs(value string) *Rule[string] {
 	return Test("string-is", func(ctx context.Context, s string) error {
 		if s != value {
-			return fmt.Errorf("string is not %s", value)
+			return NewFailure("string.not_equal",
+				fmt.Sprintf("string is not %s", value),
+				Params{"expected": value})
 		}
 		return nil
 	})
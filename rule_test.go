@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -43,6 +44,73 @@ func TestTestRule(t *testing.T) {
 	}
 }
 
+func TestTestRulePanic(t *testing.T) {
+	ctx := context.Background()
+
+	panicRule := Test("panics", func(ctx context.Context, s string) error {
+		var m map[string]int
+		m[s] = 1
+		return nil
+	})
+	result, ok := panicRule.Validate(ctx, "test")
+	if ok {
+		t.Error("Expected panicking rule to fail")
+	}
+	if result.Status != StatusError {
+		t.Errorf("Expected StatusError, got %v", result.Status)
+	}
+	if result.Panic == nil || len(result.Panic.Stack) == 0 {
+		t.Fatal("Expected panic value and stack to be recorded")
+	}
+	if !strings.HasPrefix(result.Message, "panic: ") {
+		t.Errorf("Expected panic message, got '%s'", result.Message)
+	}
+
+	// Test a panic fails its parents but does not pass a Not
+	passRule := Test("pass", func(ctx context.Context, s string) error { return nil })
+	result, ok = All(panicRule, passRule).Validate(ctx, "test")
+	if ok {
+		t.Error("Expected All with panicking child to fail")
+	}
+	if result.Children[1].Status != StatusSkip {
+		t.Error("Expected All to short-circuit on panicking child")
+	}
+	if !result.HasStatus(StatusError) {
+		t.Error("Expected HasStatus to find the errored child")
+	}
+	result, ok = Not(panicRule).Validate(ctx, "test")
+	if ok || result.Status != StatusError {
+		t.Errorf("Expected Not of panicking child to error, got %v", result.Status)
+	}
+}
+
+func TestNestedRulePanic(t *testing.T) {
+	ctx := context.Background()
+	pass := Test("pass", func(ctx context.Context, s string) error { return nil })
+
+	rules := map[string]*Rule[any]{
+		"as":    As(func(v any) (string, error) { panic("boom") }, pass),
+		"field": Field("name", func(v any) string { panic("boom") }, pass),
+		"union": Union("type", func(v any) string { panic("boom") }, map[string]*Rule[any]{}),
+		"map":   Map("convert", func(ctx context.Context, v any) (string, error) { panic("boom") }, pass),
+		"lazy":  Lazy(func() *Rule[any] { panic("boom") }),
+	}
+	for name, rule := range rules {
+		result, ok := All(rule).Validate(ctx, "test")
+		if ok {
+			t.Errorf("%s: expected panicking rule to fail", name)
+			continue
+		}
+		nested := result.Children[0]
+		if nested.Status != StatusError || nested.Code != "rule.panic" || nested.Panic == nil {
+			t.Errorf("%s: expected rule.panic error, got: %s", name, result.Format())
+		}
+		if nested.Label != rule.Label || nested.Kind != rule.Kind {
+			t.Errorf("%s: expected the node of the panicking rule, got %s (%v)", name, nested.Label, nested.Kind)
+		}
+	}
+}
+
 func TestAllRule(t *testing.T) {
 	ctx := context.Background()
 
@@ -92,6 +160,57 @@ func TestAllRule(t *testing.T) {
 	}
 }
 
+func TestAllRuleCollectAll(t *testing.T) {
+	ctx := context.Background()
+
+	fail1 := Test("fail1", func(ctx context.Context, n int) error {
+		return errors.New("first fails")
+	})
+	pass1 := Test("pass1", func(ctx context.Context, n int) error { return nil })
+	fail2 := Test("fail2", func(ctx context.Context, n int) error {
+		return errors.New("second fails")
+	})
+	allRule := All(fail1, pass1, fail2)
+
+	// Test every child is evaluated
+	result, ok := allRule.Validate(ctx, 42, CollectAll())
+	if ok {
+		t.Error("Expected All rule to fail")
+	}
+	if result.Children[0].Status != StatusFail {
+		t.Error("Expected first child to fail")
+	}
+	if result.Children[1].Status != StatusPass {
+		t.Error("Expected second child to pass")
+	}
+	if result.Children[2].Status != StatusFail {
+		t.Error("Expected third child to be evaluated and fail")
+	}
+
+	// Test option reaches rules nested through As
+	evaluated := false
+	asRule := As(AssertString, All(
+		StringLength(5, 10),
+		Test("track", func(ctx context.Context, s string) error {
+			evaluated = true
+			return nil
+		}),
+	))
+	_, ok = asRule.Validate(ctx, any("abc"), CollectAll())
+	if ok {
+		t.Error("Expected As rule to fail")
+	}
+	if !evaluated {
+		t.Error("Expected nested All to evaluate every child")
+	}
+
+	// Test default still short-circuits
+	result, _ = allRule.Validate(ctx, 42)
+	if result.Children[2].Status != StatusSkip {
+		t.Error("Expected third child to be skipped without CollectAll")
+	}
+}
+
 func TestAnyRule(t *testing.T) {
 	ctx := context.Background()
 
@@ -142,6 +261,128 @@ func TestAnyRule(t *testing.T) {
 	}
 }
 
+func TestParallel(t *testing.T) {
+	ctx := context.Background()
+
+	slow := func(label string, fail bool) *Rule[int] {
+		return Test(label, func(ctx context.Context, n int) error {
+			select {
+			case <-time.After(50 * time.Millisecond):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if fail {
+				return errors.New(label + " fails")
+			}
+			return nil
+		})
+	}
+	fastFail := Test("fast-fail", func(ctx context.Context, n int) error {
+		return errors.New("fails fast")
+	})
+	fastPass := Test("fast-pass", func(ctx context.Context, n int) error { return nil })
+
+	// Test All passes with results in declaration order
+	allRule := All(slow("slow1", false), fastPass, slow("slow2", false))
+	result, ok := allRule.Validate(ctx, 42, Parallel(0))
+	if !ok {
+		t.Errorf("Expected parallel All to pass, got: %s", result.Format())
+	}
+	for i, label := range []string{"slow1", "fast-pass", "slow2"} {
+		if result.Children[i].Label != label || result.Children[i].Status != StatusPass {
+			t.Errorf("Expected child %d to be passing %s, got: %s", i, label, result.Children[i])
+		}
+	}
+
+	// Test first failure cancels slow siblings: each one that started must
+	// see its context done. A sibling cancelled before it starts is not run
+	var mu sync.Mutex
+	started, cancelled := 0, 0
+	blocked := func(label string) *Rule[int] {
+		return Test(label, func(ctx context.Context, n int) error {
+			mu.Lock()
+			started++
+			mu.Unlock()
+			select {
+			case <-ctx.Done():
+				mu.Lock()
+				cancelled++
+				mu.Unlock()
+				return ctx.Err()
+			case <-time.After(10 * time.Second):
+				return errors.New(label + " was not cancelled")
+			}
+		})
+	}
+	allRule = All(blocked("slow1"), fastFail, blocked("slow2"))
+	result, ok = allRule.Validate(ctx, 42, Parallel(0))
+	if ok {
+		t.Error("Expected parallel All to fail")
+	}
+	mu.Lock()
+	if cancelled != started {
+		t.Errorf("Expected every started sibling to be cancelled, %d of %d were", cancelled, started)
+	}
+	mu.Unlock()
+	if result.Children[1].Status != StatusFail {
+		t.Error("Expected failing child to be reported")
+	}
+	if result.Children[0].Status != StatusSkip || result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected cancelled siblings to be skipped, got: %s", result.Format())
+	}
+
+	// Test first success decides Any
+	anyRule := Any(slow("slow1", true), fastPass, slow("slow2", true))
+	result, ok = anyRule.Validate(ctx, 42, Parallel(2))
+	if !ok {
+		t.Errorf("Expected parallel Any to pass, got: %s", result.Format())
+	}
+	if result.Children[1].Status != StatusPass {
+		t.Error("Expected passing child to be reported")
+	}
+
+	// Test all failing Any
+	anyRule = Any(fastFail, slow("slow1", true))
+	_, ok = anyRule.Validate(ctx, 42, Parallel(0))
+	if ok {
+		t.Error("Expected parallel Any to fail when all children fail")
+	}
+
+	// Test CollectAll with Parallel reports every failure
+	allRule = All(fastFail, slow("slow1", true), fastPass)
+	result, _ = allRule.Validate(ctx, 42, Parallel(0), CollectAll())
+	if result.Children[0].Status != StatusFail || result.Children[1].Status != StatusFail {
+		t.Errorf("Expected every failure to be collected, got: %s", result.Format())
+	}
+}
+
+func TestParallelLimit(t *testing.T) {
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	track := Test("track", func(ctx context.Context, n int) error {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	})
+
+	allRule := All(track, track, track, track, track, track)
+	_, ok := allRule.Validate(ctx, 42, Parallel(2))
+	if !ok {
+		t.Error("Expected parallel All to pass")
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 concurrent children, got %d", peak)
+	}
+}
+
 func TestNotRule(t *testing.T) {
 	ctx := context.Background()
 
@@ -230,6 +471,107 @@ func TestOneOf(t *testing.T) {
 	}
 }
 
+func TestCountingRules(t *testing.T) {
+	ctx := context.Background()
+
+	pass := Test("pass", func(ctx context.Context, n int) error { return nil })
+	fail := Test("fail", func(ctx context.Context, n int) error {
+		return errors.New("fails")
+	})
+
+	// Test OneOf keeps every child result
+	result, ok := OneOf(fail, pass, fail).Validate(ctx, 42)
+	if !ok {
+		t.Errorf("Expected OneOf to pass, got: %s", result.Format())
+	}
+	if result.Kind != KindOneOf || len(result.Children) != 3 {
+		t.Errorf("Expected OneOf node with 3 children, got: %s", result.Format())
+	}
+	if result.Children[0].Status != StatusFail || result.Children[1].Status != StatusPass {
+		t.Errorf("Expected child results in the trace, got: %s", result.Format())
+	}
+
+	// Test AtLeast short-circuits once enough rules passed
+	result, ok = AtLeast(2, pass, fail, pass, fail).Validate(ctx, 42)
+	if !ok {
+		t.Errorf("Expected AtLeast to pass, got: %s", result.Format())
+	}
+	if result.Kind != KindAtLeast || result.Children[3].Status != StatusSkip {
+		t.Errorf("Expected last child to be skipped, got: %s", result.Format())
+	}
+
+	// Test AtLeast short-circuits once it can no longer pass
+	result, ok = AtLeast(2, fail, fail, pass).Validate(ctx, 42)
+	if ok {
+		t.Error("Expected AtLeast to fail")
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected last child to be skipped, got: %s", result.Format())
+	}
+	if result.Message != "only 0 rules passed (expected at least 2)" {
+		t.Errorf("Expected count message, got '%s'", result.Message)
+	}
+
+	// Test AtMost
+	_, ok = AtMost(1, pass, fail, fail).Validate(ctx, 42)
+	if !ok {
+		t.Error("Expected AtMost to pass")
+	}
+	result, ok = AtMost(1, pass, pass, fail).Validate(ctx, 42)
+	if ok {
+		t.Error("Expected AtMost to fail")
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected last child to be skipped, got: %s", result.Format())
+	}
+
+	// Test Exactly
+	_, ok = Exactly(2, pass, fail, pass).Validate(ctx, 42)
+	if !ok {
+		t.Error("Expected Exactly to pass")
+	}
+	result, ok = Exactly(2, pass, pass, pass).Validate(ctx, 42)
+	if ok {
+		t.Error("Expected Exactly to fail")
+	}
+	if result.Message != "3 rules passed (expected exactly 2)" {
+		t.Errorf("Expected count message, got '%s'", result.Message)
+	}
+
+	// Test the pass count is reported on a pass too
+	result, _ = AtLeast(1, fail, pass).Validate(ctx, 42)
+	if result.Params["passed"] != 1 {
+		t.Errorf("Expected passed count 1, got: %v", result.Params)
+	}
+
+	// Test a broken child does not turn into a count that passes
+	broken := Test("broken", func(ctx context.Context, n int) error {
+		panic("boom")
+	})
+	result, ok = AtMost(0, broken).Validate(ctx, 42)
+	if ok || result.Status != StatusError || result.Code != "count.child_errored" {
+		t.Errorf("Expected AtMost to error, got: %s", result.Format())
+	}
+	block := make(chan struct{})
+	defer close(block)
+	stuck := WithTimeout(10*time.Millisecond, Test("stuck", func(ctx context.Context, n int) error {
+		<-block
+		return nil
+	}))
+	result, ok = Exactly(0, stuck).Validate(ctx, 42)
+	if ok || result.Status != StatusTimeout || result.Code != "count.child_timed_out" {
+		t.Errorf("Expected Exactly to time out, got: %s", result.Format())
+	}
+
+	// Test a broken child is ignored when the others decide the count
+	if result, ok := AtLeast(1, broken, pass).Validate(ctx, 42); !ok {
+		t.Errorf("Expected AtLeast to pass, got: %s", result.Format())
+	}
+	if result, ok := AtMost(0, broken, pass).Validate(ctx, 42); ok || result.Status != StatusFail {
+		t.Errorf("Expected AtMost to fail, got: %s", result.Format())
+	}
+}
+
 func TestNewRule(t *testing.T) {
 	ctx := context.Background()
 
@@ -323,6 +665,46 @@ func TestAs(t *testing.T) {
 	}
 }
 
+func TestAsResultTree(t *testing.T) {
+	ctx := context.Background()
+
+	asRule := As(AssertString, All(
+		StringLength(3, 10),
+		StringContains("@"),
+	))
+
+	// Test the nested failure is kept in the tree
+	result, ok := asRule.Validate(ctx, "hello")
+	if ok {
+		t.Error("Expected As rule to fail")
+	}
+	if result.Kind != KindAs {
+		t.Errorf("Expected KindAs, got %v", result.Kind)
+	}
+	if len(result.Children) != 1 || result.Children[0].Kind != KindAll {
+		t.Fatalf("Expected nested All result, got: %s", result.Format())
+	}
+	nested := result.Children[0].Children[1]
+	if nested.Label != "string-contains" || nested.Status != StatusFail {
+		t.Errorf("Expected failing string-contains node, got: %s", result.Format())
+	}
+	if !strings.Contains(result.Format(), "string does not contain @") {
+		t.Errorf("Expected Format to show nested message, got: %s", result.Format())
+	}
+
+	// Test transform failure adds a transform node and skips the nested rule
+	result, _ = asRule.Validate(ctx, 42)
+	if len(result.Children) != 2 {
+		t.Fatalf("Expected transform and skipped nodes, got: %s", result.Format())
+	}
+	if result.Children[0].Label != "transform" || result.Children[0].Status != StatusFail {
+		t.Errorf("Expected failing transform node, got: %s", result.Children[0])
+	}
+	if result.Children[1].Status != StatusSkip {
+		t.Errorf("Expected nested rule to be skipped, got: %s", result.Children[1])
+	}
+}
+
 func TestAsWithTransformation(t *testing.T) {
 	ctx := context.Background()
 
@@ -413,6 +795,95 @@ func TestContextTimeout(t *testing.T) {
 	}
 }
 
+func TestRuleTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	// Test a TestFn ignoring its context is abandoned
+	block := make(chan struct{})
+	defer close(block)
+	stuck := Test("stuck", func(ctx context.Context, s string) error {
+		<-block
+		return nil
+	})
+	timed := WithTimeout(10*time.Millisecond, stuck)
+	if stuck.Timeout != 0 {
+		t.Error("Expected WithTimeout to leave the original rule unchanged")
+	}
+
+	start := time.Now()
+	result, ok := timed.Validate(ctx, "test")
+	if ok {
+		t.Error("Expected timed out rule to fail")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected TestFn to be interrupted, took %v", elapsed)
+	}
+	if result.Status != StatusTimeout {
+		t.Errorf("Expected StatusTimeout, got %v", result.Status)
+	}
+	if result.Message != "timed out after 10ms" {
+		t.Errorf("Expected timeout message, got '%s'", result.Message)
+	}
+
+	// Test a combinator timeout is reported on the combinator
+	pass := Test("pass", func(ctx context.Context, s string) error { return nil })
+	result, ok = WithTimeout(10*time.Millisecond, All(pass, stuck)).Validate(ctx, "test")
+	if ok {
+		t.Error("Expected timed out All to fail")
+	}
+	if result.Status != StatusTimeout || result.Children[1].Status != StatusTimeout {
+		t.Errorf("Expected All and its slow child to time out, got: %s", result.Format())
+	}
+
+	// Test Not does not turn a timed out child into a pass
+	result, ok = All(Not(timed)).Validate(ctx, "test")
+	if ok {
+		t.Error("Expected Not over a timed out rule to fail")
+	}
+	if not := result.Children[0]; not.Status != StatusTimeout || not.Code != "not.child_timed_out" {
+		t.Errorf("Expected Not to time out with not.child_timed_out, got: %s", result.Format())
+	}
+
+	// Test fast rules are unaffected
+	_, ok = WithTimeout(time.Second, pass).Validate(ctx, "test")
+	if !ok {
+		t.Error("Expected fast rule to pass within its timeout")
+	}
+}
+
+func TestBudget(t *testing.T) {
+	ctx := context.Background()
+
+	slow := Test("slow", func(ctx context.Context, s string) error {
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		return nil
+	})
+	pass := Test("pass", func(ctx context.Context, s string) error { return nil })
+
+	result, ok := All(pass, slow, pass).Validate(ctx, "test", Budget(10*time.Millisecond))
+	if ok {
+		t.Error("Expected validation to fail when the budget runs out")
+	}
+	if result.Children[1].Status != StatusTimeout {
+		t.Errorf("Expected slow child to time out, got: %s", result.Format())
+	}
+	if !strings.Contains(result.Children[1].Message, "budget exceeded") {
+		t.Errorf("Expected budget message, got '%s'", result.Children[1].Message)
+	}
+
+	// Test cancellation by the caller is not reported as a timeout
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	result, _ = slow.Validate(cancelled, "test", Budget(time.Second))
+	if result.Status != StatusFail || result.Message != "context cancelled" {
+		t.Errorf("Expected plain cancellation, got: %s", result.Format())
+	}
+}
+
 func TestNestedRules(t *testing.T) {
 	ctx := context.Background()
 
@@ -494,6 +965,104 @@ func TestResultFormat(t *testing.T) {
 	}
 }
 
+type codeError struct {
+	code string
+}
+
+func (e *codeError) Error() string {
+	return "code " + e.code
+}
+
+func TestResultErr(t *testing.T) {
+	ctx := context.Background()
+
+	errNotFound := errors.New("not found")
+	notFound := Test("lookup", func(ctx context.Context, s string) error {
+		return fmt.Errorf("user %q: %w", s, errNotFound)
+	})
+	coded := Test("coded", func(ctx context.Context, s string) error {
+		return &codeError{code: "E42"}
+	})
+	pass := Test("pass", func(ctx context.Context, s string) error { return nil })
+
+	// Test a passing result has no error
+	result, _ := pass.Validate(ctx, "bob")
+	if result.Err() != nil {
+		t.Errorf("Expected nil error for passing result, got %v", result.Err())
+	}
+
+	// Test original errors are reachable through the tree
+	rule := As(AssertString, All(pass, notFound, coded))
+	result, _ = rule.Validate(ctx, "bob", CollectAll())
+	err := result.Err()
+	if err == nil {
+		t.Fatal("Expected error for failing result")
+	}
+	if !errors.Is(err, errNotFound) {
+		t.Error("Expected errors.Is to find the sentinel error")
+	}
+	var codeErr *codeError
+	if !errors.As(err, &codeErr) || codeErr.code != "E42" {
+		t.Error("Expected errors.As to find the typed error")
+	}
+	if result.Children[0].Children[1].Cause == nil {
+		t.Error("Expected failing leaf to keep its cause")
+	}
+	if err.Error() != `[FAIL] as: lookup: user "bob": not found; coded: code E42` {
+		t.Errorf("Unexpected error message: %s", err.Error())
+	}
+
+	// Test fmt prints results with Error, which starts like String
+	if got := fmt.Sprint(result); got != err.Error() {
+		t.Errorf("Expected fmt to print the error message, got: %s", got)
+	}
+	passed, _ := pass.Validate(ctx, "bob")
+	if got := fmt.Sprintf("%v", passed); got != "[PASS] pass" {
+		t.Errorf("Expected a passing result to print as String, got: %s", got)
+	}
+
+	// Test the result can be joined with other errors
+	joined := errors.Join(errors.New("other"), result)
+	if !errors.Is(joined, errNotFound) {
+		t.Error("Expected joined error to keep the result tree")
+	}
+}
+
+func TestFailureCodes(t *testing.T) {
+	ctx := context.Background()
+
+	// Test built-ins report codes and typed params
+	result, _ := StringLength(3, 10).Validate(ctx, "a")
+	if result.Code != "string.too_short" {
+		t.Errorf("Expected code 'string.too_short', got '%s'", result.Code)
+	}
+	if result.Params["min"] != 3 || result.Params["got"] != 1 {
+		t.Errorf("Expected min and got params, got %v", result.Params)
+	}
+	if result.Message != "string too short (min: 3, got: 1)" {
+		t.Errorf("Expected English message to be kept, got '%s'", result.Message)
+	}
+
+	// Test custom failures, including wrapped ones
+	custom := Test("custom", func(ctx context.Context, n int) error {
+		return fmt.Errorf("checking %d: %w", n, NewFailure("number.odd", "number is odd", Params{"value": n}))
+	})
+	result, _ = custom.Validate(ctx, 3)
+	if result.Code != "number.odd" || result.Params["value"] != 3 {
+		t.Errorf("Expected code and params from wrapped failure, got %s %v", result.Code, result.Params)
+	}
+
+	// Test combinators and transforms report codes
+	result, _ = AtLeast(1, custom).Validate(ctx, 3)
+	if result.Code != "count.too_few" || result.Params["min"] != 1 {
+		t.Errorf("Expected count code, got %s %v", result.Code, result.Params)
+	}
+	result, _ = As(AssertString, StringIs("x")).Validate(ctx, 42)
+	if result.Code != "as.transform_failed" || result.Children[0].Code != "type.not_string" {
+		t.Errorf("Expected transform codes, got: %s", result.Format())
+	}
+}
+
 func TestNotRuleInvalidChildren(t *testing.T) {
 	ctx := context.Background()
 
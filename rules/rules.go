@@ -4,12 +4,12 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net"
 	"net/url"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/johan-st/gook"
 )
@@ -21,7 +21,7 @@ func Email() *gook.Rule[string] {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 	return gook.Test("email", func(ctx context.Context, value string) error {
 		if !emailRegex.MatchString(value) {
-			return errors.New("invalid email format")
+			return gook.NewFailure("email.invalid", "invalid email format", nil)
 		}
 		return nil
 	})
@@ -32,13 +32,13 @@ func URL() *gook.Rule[string] {
 	return gook.Test("url", func(ctx context.Context, value string) error {
 		u, err := url.Parse(value)
 		if err != nil {
-			return fmt.Errorf("invalid URL format: %v", err)
+			return gook.NewFailure("url.invalid", fmt.Sprintf("invalid URL format: %v", err), gook.Params{"error": err.Error()})
 		}
 		if u.Scheme == "" {
-			return errors.New("URL must have a scheme (e.g., http, https)")
+			return gook.NewFailure("url.missing_scheme", "URL must have a scheme (e.g., http, https)", nil)
 		}
 		if u.Host == "" {
-			return errors.New("URL must have a host")
+			return gook.NewFailure("url.missing_host", "URL must have a host", nil)
 		}
 		return nil
 	})
@@ -50,15 +50,16 @@ func PhoneUS() *gook.Rule[string] {
 	// US phone: 10 digits, optional country code +1, various separators
 	phoneRegex := regexp.MustCompile(`^(\+?1[-.\s]?)?\(?([0-9]{3})\)?[-.\s]?([0-9]{3})[-.\s]?([0-9]{4})$`)
 	return gook.Test("phone-us", func(ctx context.Context, value string) error {
-		cleaned := regexp.MustCompile(`[^\d]`).ReplaceAllString(value, "")
+		cleaned := digits(value)
 		if len(cleaned) < 10 || len(cleaned) > 11 {
-			return errors.New("US phone number must have 10 or 11 digits")
+			return gook.NewFailure("phone.digit_count", "US phone number must have 10 or 11 digits",
+				gook.Params{"min": 10, "max": 11, "got": len(cleaned)})
 		}
 		if len(cleaned) == 11 && cleaned[0] != '1' {
-			return errors.New("US phone number with country code must start with 1")
+			return gook.NewFailure("phone.country_code", "US phone number with country code must start with 1", nil)
 		}
 		if !phoneRegex.MatchString(value) {
-			return errors.New("invalid US phone number format")
+			return gook.NewFailure("phone.invalid", "invalid US phone number format", nil)
 		}
 		return nil
 	})
@@ -71,11 +72,12 @@ func PhoneInternational() *gook.Rule[string] {
 	phoneRegex := regexp.MustCompile(`^\+\d{1,3}[-.\s]?\d{1,14}[-.\s]?\d{1,14}$`)
 	return gook.Test("phone-international", func(ctx context.Context, value string) error {
 		if !phoneRegex.MatchString(value) {
-			return errors.New("invalid international phone number format (must start with +)")
+			return gook.NewFailure("phone.invalid", "invalid international phone number format (must start with +)", nil)
 		}
-		cleaned := regexp.MustCompile(`[^\d]`).ReplaceAllString(value, "")
+		cleaned := digits(value)
 		if len(cleaned) < 7 || len(cleaned) > 15 {
-			return errors.New("international phone number must have 7-15 digits")
+			return gook.NewFailure("phone.digit_count", "international phone number must have 7-15 digits",
+				gook.Params{"min": 7, "max": 15, "got": len(cleaned)})
 		}
 		return nil
 	})
@@ -86,12 +88,22 @@ func UUID() *gook.Rule[string] {
 	uuidRegex := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
 	return gook.Test("uuid", func(ctx context.Context, value string) error {
 		if !uuidRegex.MatchString(strings.ToLower(value)) {
-			return errors.New("invalid UUID v4 format (expected: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx)")
+			return gook.NewFailure("uuid.invalid", "invalid UUID v4 format (expected: xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx)", nil)
 		}
 		return nil
 	})
 }
 
+// digits returns the decimal digits of value, dropping any formatting
+func digits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r < '0' || r > '9' {
+			return -1
+		}
+		return r
+	}, value)
+}
+
 // luhnCheck validates a number using the Luhn algorithm
 func luhnCheck(number string) bool {
 	sum := 0
@@ -116,15 +128,16 @@ func CreditCard() *gook.Rule[string] {
 	// Credit card: 13-19 digits, may contain spaces or dashes
 	cardRegex := regexp.MustCompile(`^[\d\s\-]{13,19}$`)
 	return gook.Test("credit-card", func(ctx context.Context, value string) error {
-		cleaned := regexp.MustCompile(`[^\d]`).ReplaceAllString(value, "")
+		cleaned := digits(value)
 		if len(cleaned) < 13 || len(cleaned) > 19 {
-			return errors.New("credit card number must have 13-19 digits")
+			return gook.NewFailure("credit_card.digit_count", "credit card number must have 13-19 digits",
+				gook.Params{"min": 13, "max": 19, "got": len(cleaned)})
 		}
 		if !cardRegex.MatchString(value) {
-			return errors.New("invalid credit card format")
+			return gook.NewFailure("credit_card.invalid", "invalid credit card format", nil)
 		}
 		if !luhnCheck(cleaned) {
-			return errors.New("invalid credit card number (Luhn check failed)")
+			return gook.NewFailure("credit_card.checksum", "invalid credit card number (Luhn check failed)", nil)
 		}
 		return nil
 	})
@@ -135,7 +148,7 @@ func IPAddress() *gook.Rule[string] {
 	return gook.Test("ip-address", func(ctx context.Context, value string) error {
 		ip := net.ParseIP(value)
 		if ip == nil {
-			return errors.New("invalid IP address format (must be IPv4 or IPv6)")
+			return gook.NewFailure("ip.invalid", "invalid IP address format (must be IPv4 or IPv6)", nil)
 		}
 		return nil
 	})
@@ -146,10 +159,10 @@ func IPv4() *gook.Rule[string] {
 	return gook.Test("ipv4", func(ctx context.Context, value string) error {
 		ip := net.ParseIP(value)
 		if ip == nil {
-			return errors.New("invalid IPv4 address format")
+			return gook.NewFailure("ipv4.invalid", "invalid IPv4 address format", nil)
 		}
 		if ip.To4() == nil {
-			return errors.New("not an IPv4 address (use IPAddress() for IPv6 support)")
+			return gook.NewFailure("ipv4.not_v4", "not an IPv4 address (use IPAddress() for IPv6 support)", nil)
 		}
 		return nil
 	})
@@ -160,10 +173,10 @@ func IPv6() *gook.Rule[string] {
 	return gook.Test("ipv6", func(ctx context.Context, value string) error {
 		ip := net.ParseIP(value)
 		if ip == nil {
-			return errors.New("invalid IPv6 address format")
+			return gook.NewFailure("ipv6.invalid", "invalid IPv6 address format", nil)
 		}
 		if ip.To4() != nil {
-			return errors.New("not an IPv6 address (use IPv4() for IPv4 support)")
+			return gook.NewFailure("ipv6.not_v6", "not an IPv6 address (use IPv4() for IPv4 support)", nil)
 		}
 		return nil
 	})
@@ -175,10 +188,11 @@ func Domain() *gook.Rule[string] {
 	domainRegex := regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
 	return gook.Test("domain", func(ctx context.Context, value string) error {
 		if !domainRegex.MatchString(value) {
-			return errors.New("invalid domain name format")
+			return gook.NewFailure("domain.invalid", "invalid domain name format", nil)
 		}
 		if len(value) > 253 {
-			return errors.New("domain name too long (max 253 characters)")
+			return gook.NewFailure("domain.too_long", "domain name too long (max 253 characters)",
+				gook.Params{"max": 253, "got": len(value)})
 		}
 		return nil
 	})
@@ -189,7 +203,7 @@ func HexColor() *gook.Rule[string] {
 	hexColorRegex := regexp.MustCompile(`^#([0-9a-fA-F]{6}|[0-9a-fA-F]{3}|[0-9a-fA-F]{8})$`)
 	return gook.Test("hex-color", func(ctx context.Context, value string) error {
 		if !hexColorRegex.MatchString(value) {
-			return errors.New("invalid hex color format (expected #RRGGBB, #RGB, or #RRGGBBAA)")
+			return gook.NewFailure("hex_color.invalid", "invalid hex color format (expected #RRGGBB, #RGB, or #RRGGBBAA)", nil)
 		}
 		return nil
 	})
@@ -200,7 +214,7 @@ func Base64() *gook.Rule[string] {
 	return gook.Test("base64", func(ctx context.Context, value string) error {
 		_, err := base64.StdEncoding.DecodeString(value)
 		if err != nil {
-			return fmt.Errorf("invalid Base64 encoding: %v", err)
+			return gook.NewFailure("base64.invalid", fmt.Sprintf("invalid Base64 encoding: %v", err), gook.Params{"error": err.Error()})
 		}
 		return nil
 	})
@@ -211,9 +225,40 @@ func JSON() *gook.Rule[string] {
 	return gook.Test("json", func(ctx context.Context, value string) error {
 		var js interface{}
 		if err := json.Unmarshal([]byte(value), &js); err != nil {
-			return fmt.Errorf("invalid JSON format: %v", err)
+			return gook.NewFailure("json.invalid", fmt.Sprintf("invalid JSON format: %v", err), gook.Params{"error": err.Error()})
 		}
 		return nil
 	})
 }
 
+// NormalizeEmail trims an email address and lowercases it
+func NormalizeEmail() gook.Transform[string, string] {
+	return gook.Normalize("normalize-email", func(value string) string {
+		return strings.ToLower(strings.TrimSpace(value))
+	})
+}
+
+// NormalizePhone strips the formatting from a phone number, keeping its
+// digits and a leading + (e.g. "+44 20 7946 0958" becomes "+442079460958")
+// Anything but spaces, dashes, dots and parentheses is kept for the
+// validating rule to reject
+func NormalizePhone() gook.Transform[string, string] {
+	return gook.Normalize("normalize-phone", stripFormatting)
+}
+
+// NormalizeCreditCard strips the formatting from a credit card number,
+// like NormalizePhone (e.g. "4532-0151-1283-0366" becomes "4532015112830366")
+func NormalizeCreditCard() gook.Transform[string, string] {
+	return gook.Normalize("normalize-credit-card", stripFormatting)
+}
+
+// stripFormatting drops the white space, dashes, dots and parentheses that
+// format a number
+func stripFormatting(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || strings.ContainsRune("-.()", r) {
+			return -1
+		}
+		return r
+	}, value)
+}
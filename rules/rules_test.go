@@ -3,6 +3,8 @@ package rules
 import (
 	"context"
 	"testing"
+
+	"github.com/johan-st/gook"
 )
 
 func TestEmail(t *testing.T) {
@@ -300,3 +302,75 @@ func TestJSON(t *testing.T) {
 	}
 }
 
+func TestFailureCodes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		rule  *gook.Rule[string]
+		value string
+		code  string
+	}{
+		{"email", Email(), "invalid", "email.invalid"},
+		{"url scheme", URL(), "example.com", "url.missing_scheme"},
+		{"credit card checksum", CreditCard(), "4532015112830367", "credit_card.checksum"},
+		{"ipv4", IPv4(), "::1", "ipv4.not_v4"},
+		{"json", JSON(), "{", "json.invalid"},
+	}
+
+	for _, tt := range tests {
+		result, ok := tt.rule.Validate(ctx, tt.value)
+		if ok {
+			t.Errorf("%s: expected %q to be invalid", tt.name, tt.value)
+			continue
+		}
+		if result.Code != tt.code {
+			t.Errorf("%s: expected code %q, got %q", tt.name, tt.code, result.Code)
+		}
+	}
+
+	result, _ := CreditCard().Validate(ctx, "1234")
+	if result.Params["got"] != 4 {
+		t.Errorf("Expected digit count param, got %v", result.Params)
+	}
+}
+
+func TestNormalizers(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		parser *gook.Parser[string, string]
+		input  string
+		want   string
+	}{
+		{"email", gook.NewParser(NormalizeEmail(), Email()), "  Ada@Example.COM ", "ada@example.com"},
+		{"phone us", gook.NewParser(NormalizePhone(), PhoneUS()), "(555) 123-4567", "5551234567"},
+		{"phone international", gook.NewParser(NormalizePhone(), PhoneInternational()), " +44 20 7946 0958", "+442079460958"},
+		{"credit card", gook.NewParser(NormalizeCreditCard(), CreditCard()), "4532-0151-1283-0366", "4532015112830366"},
+	}
+
+	for _, tt := range tests {
+		got, result, ok := tt.parser.Parse(ctx, tt.input)
+		if !ok {
+			t.Errorf("%s: expected %q to be valid, got: %s", tt.name, tt.input, result.Format())
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected canonical %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	// Test the canonical form is what gets validated
+	if _, result, ok := gook.NewParser(NormalizeCreditCard(), CreditCard()).Parse(ctx, "4532 0151 1283 0367"); ok {
+		t.Errorf("Expected checksum failure, got: %s", result.Format())
+	}
+
+	// Test characters other than formatting are left for the rule to reject
+	if _, result, ok := gook.NewParser(NormalizeCreditCard(), CreditCard()).Parse(ctx, "card: 4532 0151 1283 0366 (visa)"); ok {
+		t.Errorf("Expected card number with text to fail, got: %s", result.Format())
+	}
+	if _, result, ok := gook.NewParser(NormalizePhone(), PhoneUS()).Parse(ctx, "call 555-123-4567 now"); ok {
+		t.Errorf("Expected phone number with text to fail, got: %s", result.Format())
+	}
+}
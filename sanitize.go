@@ -0,0 +1,33 @@
+package gook
+
+import (
+	"context"
+	"strings"
+)
+
+// Normalize creates a transform that rewrites values into a canonical form
+// with fn before they are validated. Used with NewParser, the canonical value
+// that was validated is what Parse returns
+func Normalize[T any](name string, fn func(T) T) Transform[T, T] {
+	return Step(name, func(ctx context.Context, v T) (T, error) {
+		return fn(v), nil
+	})
+}
+
+// Trim removes leading and trailing white space
+func Trim() Transform[string, string] {
+	return Normalize("trim", strings.TrimSpace)
+}
+
+// Lowercase maps letters to lower case
+func Lowercase() Transform[string, string] {
+	return Normalize("lowercase", strings.ToLower)
+}
+
+// CollapseSpaces trims white space and replaces each inner run of white
+// space with a single space
+func CollapseSpaces() Transform[string, string] {
+	return Normalize("collapse-spaces", func(s string) string {
+		return strings.Join(strings.Fields(s), " ")
+	})
+}
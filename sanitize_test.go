@@ -0,0 +1,25 @@
+package gook
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	ctx := context.Background()
+	name := NewParser(Pipe(CollapseSpaces(), Lowercase()), StringLength(1, 20))
+
+	got, result, ok := name.Parse(ctx, "  Ada \t  LOVELACE\n")
+	if !ok || got != "ada lovelace" {
+		t.Errorf("Expected canonical %q, got %q, %v: %s", "ada lovelace", got, ok, result.Format())
+	}
+	if result.Label != "collapse-spaces" || result.Children[0].Label != "lowercase" {
+		t.Errorf("Expected normalizers as nodes, got: %s", result.Format())
+	}
+
+	// Test normalizers compose with rules directly
+	rule := Trim()(StringIs("ok"))
+	if _, ok := rule.Validate(ctx, " ok "); !ok {
+		t.Error("Expected trimmed value to pass")
+	}
+}
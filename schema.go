@@ -0,0 +1,209 @@
+package gook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"math"
+	"slices"
+	"unsafe"
+)
+
+// Number is the set of types JSONNumber coerces decoded JSON numbers into
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Property declares a property of a JSONObject schema
+// Create one with Prop, OptionalProp, AdditionalProps or NoAdditionalProps
+type Property struct {
+	name       string
+	rule       *Rule[any]
+	optional   bool
+	additional bool // applies to properties not declared by name
+}
+
+// Prop declares a required property validated by rule
+func Prop(name string, rule *Rule[any]) Property {
+	return Property{name: name, rule: rule}
+}
+
+// OptionalProp declares a property validated by rule when it is present
+func OptionalProp(name string, rule *Rule[any]) Property {
+	return Property{name: name, rule: rule, optional: true}
+}
+
+// AdditionalProps validates the properties not declared by name with rule
+// Without it, undeclared properties are allowed and not validated
+func AdditionalProps(rule *Rule[any]) Property {
+	return Property{rule: rule, additional: true}
+}
+
+// NoAdditionalProps rejects properties not declared by name
+func NoAdditionalProps() Property {
+	return AdditionalProps(Test("declared", func(ctx context.Context, value any) error {
+		return NewFailure("object.unknown_property", "unknown property", nil)
+	}))
+}
+
+// JSONObject creates a rule for objects decoded from JSON as map[string]any
+// Declared properties are validated in order, each at its own path, followed
+// by the additional properties in sorted order. Properties are combined like
+// All; use the CollectAll option to report every property
+func JSONObject(label string, props ...Property) *Rule[any] {
+	fields := []*Rule[any]{
+		Test("object", func(ctx context.Context, value any) error {
+			if _, ok := value.(map[string]any); !ok {
+				return NewFailure("type.not_object", "value is not an object", nil)
+			}
+			return nil
+		}),
+	}
+
+	declared := make(map[string]bool)
+	var additional *Rule[any]
+	for _, prop := range props {
+		if prop.additional {
+			additional = prop.rule
+			continue
+		}
+		declared[prop.name] = true
+		fields = append(fields, property(prop))
+	}
+	if additional != nil {
+		fields = append(fields, Elements("additional", func(value any) iter.Seq2[string, any] {
+			return func(yield func(string, any) bool) {
+				obj, _ := value.(map[string]any)
+				for _, name := range slices.Sorted(maps.Keys(obj)) {
+					if !declared[name] && !yield(name, obj[name]) {
+						return
+					}
+				}
+			}
+		}, additional))
+	}
+	return Object(label, fields...)
+}
+
+// property creates the field rule of a declared property
+func property(prop Property) *Rule[any] {
+	return &Rule[any]{
+		Label: prop.name,
+		Kind:  KindField,
+		eval: func(ctx context.Context, value any) *Result {
+			ctx, path := descend(ctx, prop.name)
+			obj, ok := value.(map[string]any)
+			if !ok {
+				// Reported by the object type test
+				return &Result{Status: StatusSkip, Path: path}
+			}
+
+			v, found := obj[prop.name]
+			switch {
+			case !found && prop.optional:
+				return &Result{Status: StatusSkip, Path: path}
+			case !found:
+				return &Result{
+					Status:  StatusFail,
+					Path:    path,
+					Message: "missing required property",
+					Code:    "object.missing_property",
+					Params:  Params{"property": prop.name},
+				}
+			}
+			result := wrap(prop.rule.validateRecursive(ctx, v))
+			result.Path = path
+			return result
+		},
+	}
+}
+
+// JSONString creates a rule for decoded JSON strings validated by rule
+func JSONString(rule *Rule[string]) *Rule[any] {
+	return As(AssertString, rule)
+}
+
+// JSONBool creates a rule for decoded JSON booleans validated by rule
+func JSONBool(rule *Rule[bool]) *Rule[any] {
+	return As(func(v any) (bool, error) {
+		b, ok := v.(bool)
+		if !ok {
+			return false, NewFailure("type.not_bool", "value is not a boolean", nil)
+		}
+		return b, nil
+	}, rule)
+}
+
+// JSONNumber creates a rule for decoded JSON numbers validated by rule
+// The float64 (or json.Number) from encoding/json is coerced into N, failing
+// for fractions when N is an integer type and for numbers out of its range
+func JSONNumber[N Number](rule *Rule[N]) *Rule[any] {
+	return As(coerceNumber[N], rule)
+}
+
+// JSONArray creates a rule for decoded JSON arrays whose elements are each
+// validated by rule, at the path of their index
+func JSONArray(rule *Rule[any]) *Rule[any] {
+	return As(func(v any) ([]any, error) {
+		values, ok := v.([]any)
+		if !ok {
+			return nil, NewFailure("type.not_array", "value is not an array", nil)
+		}
+		return values, nil
+	}, Each(rule))
+}
+
+// coerceNumber converts a decoded JSON number into N
+func coerceNumber[N Number](v any) (N, error) {
+	var f float64
+	switch v := v.(type) {
+	case N:
+		return v, nil
+	case float64:
+		f = v
+	case json.Number:
+		var err error
+		if f, err = v.Float64(); err != nil {
+			return 0, NewFailure("type.not_number", "value is not a number", nil)
+		}
+	default:
+		return 0, NewFailure("type.not_number", "value is not a number", nil)
+	}
+
+	// Conversions of floats out of the range of N are implementation-defined,
+	// so the range is checked before converting
+	var n N
+	bits := int(unsafe.Sizeof(n)) * 8
+	half := 0.5
+	if N(half) != 0 {
+		// Floating point types only lose precision, unless f is out of range
+		if bits == 32 && math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+			return 0, numberOutOfRange(f, n)
+		}
+		return N(f), nil
+	}
+	if f != math.Trunc(f) {
+		return 0, NewFailure("number.not_integer",
+			fmt.Sprintf("number %v is not an integer", f),
+			Params{"got": f})
+	}
+	min, max := -math.Ldexp(1, bits-1), math.Ldexp(1, bits-1)
+	if n-1 > n {
+		// Unsigned
+		min, max = 0, math.Ldexp(1, bits)
+	}
+	if f < min || f >= max {
+		return 0, numberOutOfRange(f, n)
+	}
+	return N(f), nil
+}
+
+func numberOutOfRange(f float64, n any) error {
+	return NewFailure("number.out_of_range",
+		fmt.Sprintf("number %v out of range for %T", f, n),
+		Params{"got": f, "type": fmt.Sprintf("%T", n)})
+}
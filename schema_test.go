@@ -0,0 +1,130 @@
+package gook
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func decodeJSON(t *testing.T, src string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(src), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestJSONObject(t *testing.T) {
+	ctx := context.Background()
+	positive := Test("positive", func(ctx context.Context, n int) error {
+		if n <= 0 {
+			return NewFailure("number.not_positive", "number is not positive", nil)
+		}
+		return nil
+	})
+	rule := JSONObject("webhook",
+		Prop("event", JSONString(StringLength(1, 32))),
+		Prop("attempt", JSONNumber(positive)),
+		OptionalProp("test", JSONBool(Test("any", func(ctx context.Context, b bool) error { return nil }))),
+		Prop("items", JSONArray(JSONObject("item",
+			Prop("sku", JSONString(StringLength(4, 4))),
+			Prop("qty", JSONNumber(positive)),
+		))),
+		NoAdditionalProps(),
+	)
+
+	valid := `{"event": "order.created", "attempt": 1, "items": [{"sku": "A100", "qty": 2}]}`
+	if result, ok := rule.Validate(ctx, decodeJSON(t, valid)); !ok {
+		t.Errorf("Expected valid payload to pass, got: %s", result.Format())
+	}
+
+	invalid := `{"event": "", "attempt": 1.5, "items": [{"sku": "A100", "qty": 2}, {"sku": "B", "qty": "3"}], "extra": true}`
+	result, ok := rule.Validate(ctx, decodeJSON(t, invalid), CollectAll())
+	if ok {
+		t.Error("Expected invalid payload to fail")
+	}
+	want := map[string][]string{
+		"/event":       {"string too short (min: 1, got: 0)"},
+		"/attempt":     {"number 1.5 is not an integer"},
+		"/items/1/sku": {"string too short (min: 4, got: 1)"},
+		"/items/1/qty": {"value is not a number"},
+		"/extra":       {"unknown property"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test missing required properties and wrong types
+	result, _ = rule.Validate(ctx, decodeJSON(t, `{"event": "ping"}`), CollectAll())
+	want = map[string][]string{
+		"/attempt": {"missing required property"},
+		"/items":   {"missing required property"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	result, _ = rule.Validate(ctx, decodeJSON(t, `[1, 2]`), CollectAll())
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"": {"value is not an object"}}) {
+		t.Errorf("Expected only the object type failure, got %v", got)
+	}
+}
+
+func TestJSONAdditionalProps(t *testing.T) {
+	ctx := context.Background()
+	rule := JSONObject("labels",
+		Prop("name", JSONString(StringLength(1, 10))),
+		AdditionalProps(JSONString(StringLength(0, 3))),
+	)
+
+	result, ok := rule.Validate(ctx, decodeJSON(t, `{"name": "web", "b": "long", "a": "ok"}`))
+	if ok {
+		t.Error("Expected long additional property to fail")
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/b": {"string too long (max: 3, got: 4)"}}) {
+		t.Errorf("Expected failure at /b, got %v", got)
+	}
+}
+
+func TestJSONNumber(t *testing.T) {
+	ctx := context.Background()
+	any8 := JSONNumber(Test("any", func(ctx context.Context, n int8) error { return nil }))
+	anyFloat := JSONNumber(Test("any", func(ctx context.Context, n float32) error { return nil }))
+	any64 := JSONNumber(Test("any", func(ctx context.Context, n int64) error { return nil }))
+	anyUint := JSONNumber(Test("any", func(ctx context.Context, n uint64) error { return nil }))
+
+	tests := []struct {
+		rule  *Rule[any]
+		value any
+		code  string
+	}{
+		{any8, 12.0, ""},
+		{any8, json.Number("-12"), ""},
+		{any8, 300.0, "number.out_of_range"},
+		{any8, 1.25, "number.not_integer"},
+		{any8, "12", "type.not_number"},
+		{anyFloat, 1.25, ""},
+		{anyFloat, 1e300, "number.out_of_range"},
+		{any8, 127.0, ""},
+		{any8, -128.0, ""},
+		{any8, 128.0, "number.out_of_range"},
+		{any64, -math.Ldexp(1, 63), ""},
+		{any64, math.Ldexp(1, 63), "number.out_of_range"},
+		{anyUint, -1.0, "number.out_of_range"},
+		{anyUint, math.Ldexp(1, 64), "number.out_of_range"},
+	}
+	for _, tt := range tests {
+		result, ok := tt.rule.Validate(ctx, tt.value)
+		if tt.code == "" {
+			if !ok {
+				t.Errorf("Expected %v to pass, got: %s", tt.value, result.Format())
+			}
+			continue
+		}
+		if ok || result.Children[0].Code != tt.code {
+			t.Errorf("Expected %v to fail with %s, got: %s", tt.value, tt.code, result.Format())
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package gook
+
+// Severity is how seriously a failing rule is taken
+type Severity int
+
+const (
+	SeverityError   Severity = iota // a failure fails the validation
+	SeverityWarning                 // a failure is reported as a warning
+	SeverityInfo                    // a failure is reported as information
+)
+
+// String returns a human-readable representation of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	default:
+		return "unknown"
+	}
+}
+
+// WithSeverity returns a copy of rule whose failures are reported with severity
+// A warning or info failure has StatusWarn, which counts as OK. Only plain
+// failures are demoted; errors and timeouts still fail the validation.
+//
+// Warnings propagate through combinators as follows:
+//   - All continues past a warning and reports StatusWarn if nothing failed
+//   - Any stops at the first pass; if no child passed but one warned, it warns
+//   - Some works like Any on the elements of a slice
+//   - Not and None treat a warning as a failed check and pass, dropping the warning
+//   - counting kinds count only children that passed without a warning
+//   - As reports the status of the nested rule, so warnings pass through
+func WithSeverity[T any](severity Severity, rule *Rule[T]) *Rule[T] {
+	demoted := *rule
+	demoted.Severity = severity
+	return &demoted
+}
+
+// applySeverity demotes a failure of a rule with a lower severity to a warning
+func (r *Rule[T]) applySeverity(result *Result) *Result {
+	if r.Severity != SeverityError && result.Status == StatusFail {
+		result.Status = StatusWarn
+		result.Severity = r.Severity
+	}
+	return result
+}
+
+// warnings returns StatusWarn and the most severe warning among the children,
+// or StatusPass if none of them warned
+func warnings(children []*Result) (ResultStatus, Severity) {
+	status, severity := StatusPass, SeverityError
+	for _, child := range children {
+		if child.Status != StatusWarn {
+			continue
+		}
+		if status == StatusPass || child.Severity < severity {
+			severity = child.Severity
+		}
+		status = StatusWarn
+	}
+	return status, severity
+}
@@ -0,0 +1,74 @@
+package gook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSeverity(t *testing.T) {
+	ctx := context.Background()
+
+	weak := WithSeverity(SeverityWarning, Test("strong-password", func(ctx context.Context, s string) error {
+		if len(s) < 12 {
+			return errors.New("password is weak")
+		}
+		return nil
+	}))
+	hint := WithSeverity(SeverityInfo, StringContains("!"))
+	required := StringLength(8, 64)
+
+	// Test a warning keeps the result OK
+	result, ok := All(required, weak, hint).Validate(ctx, "password")
+	if !ok {
+		t.Errorf("Expected warnings to keep the result OK, got: %s", result.Format())
+	}
+	if result.Status != StatusWarn || result.Severity != SeverityWarning {
+		t.Errorf("Expected All to report the warning, got %v %v", result.Status, result.Severity)
+	}
+	if result.Children[1].Status != StatusWarn || result.Children[2].Severity != SeverityInfo {
+		t.Errorf("Expected warning and info children, got: %s", result.Format())
+	}
+	if result.Err() != nil {
+		t.Errorf("Expected no error for warnings, got %v", result.Err())
+	}
+	formatted := result.Format()
+	if !strings.Contains(formatted, "[WARN] strong-password (test): password is weak") {
+		t.Errorf("Expected Format to show the warning, got: %s", formatted)
+	}
+	if !strings.Contains(formatted, "[INFO] string-contains") {
+		t.Errorf("Expected Format to show the info, got: %s", formatted)
+	}
+
+	// Test hard failures still fail
+	_, ok = All(required, weak).Validate(ctx, "short")
+	if ok {
+		t.Error("Expected hard failure to fail despite warnings")
+	}
+
+	// Test Any prefers a clean pass and otherwise warns
+	result, _ = Any(weak, required).Validate(ctx, "password")
+	if result.Status != StatusPass || result.Children[1].Status != StatusPass {
+		t.Errorf("Expected Any to pass on the clean child, got: %s", result.Format())
+	}
+	result, ok = Any(weak, StringIs("x")).Validate(ctx, "password")
+	if !ok || result.Status != StatusWarn {
+		t.Errorf("Expected Any to warn, got: %s", result.Format())
+	}
+
+	// Test Not treats a warning as a failed check
+	result, ok = Not(weak).Validate(ctx, "password")
+	if !ok || result.Status != StatusPass {
+		t.Errorf("Expected Not of a warning to pass, got: %s", result.Format())
+	}
+
+	// Test errors are not demoted
+	broken := WithSeverity(SeverityWarning, Test("broken", func(ctx context.Context, s string) error {
+		panic("bug")
+	}))
+	_, ok = broken.Validate(ctx, "password")
+	if ok {
+		t.Error("Expected a panicking warning rule to still fail")
+	}
+}
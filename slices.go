@@ -0,0 +1,159 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"strconv"
+)
+
+// Each creates a rule that validates every element of a slice against rule,
+// recording the index of each element as a path segment. Like All, it stops
+// at the first failing element unless the CollectAll option is set
+func Each[T any](rule *Rule[T]) *Rule[[]T] {
+	return Elements("each", indexed[T], rule)
+}
+
+// Some creates a rule that passes if at least one element of a slice passes
+// rule. It stops at the first passing element and reports the rest as skipped.
+// Like Any, it warns rather than fails if no element passed but one warned
+func Some[T any](rule *Rule[T]) *Rule[[]T] {
+	return &Rule[[]T]{
+		Label: "some",
+		Kind:  KindSome,
+		eval: func(ctx context.Context, values []T) *Result {
+			children := make([]*Result, 0, len(values))
+			passed := false
+			for i, v := range values {
+				itemCtx, item := newItem(ctx, strconv.Itoa(i))
+				children = append(children, item)
+				if passed {
+					continue
+				}
+				childResult := rule.validateRecursive(itemCtx, v)
+				item.Status = childResult.Status
+				item.Severity = childResult.Severity
+				item.Children = []*Result{childResult}
+				passed = childResult.Status == StatusPass
+			}
+
+			if passed {
+				return &Result{Status: StatusPass, Children: children}
+			}
+			status, severity := warnings(children)
+			if status != StatusWarn {
+				status = StatusFail
+			}
+			return &Result{
+				Status:   status,
+				Severity: severity,
+				Message:  fmt.Sprintf("no element passed %s", rule.Label),
+				Code:     "some.none_passed",
+				Params:   Params{"rule": rule.Label},
+				Children: children,
+			}
+		},
+	}
+}
+
+// None creates a rule that passes if no element of a slice passes rule.
+// The first passing, errored or timed out element decides the rule and the
+// rest are reported as skipped
+func None[T any](rule *Rule[T]) *Rule[[]T] {
+	return &Rule[[]T]{
+		Label: "none",
+		Kind:  KindNone,
+		eval: func(ctx context.Context, values []T) *Result {
+			children := make([]*Result, 0, len(values))
+			status := StatusPass
+			for i, v := range values {
+				itemCtx, item := newItem(ctx, strconv.Itoa(i))
+				children = append(children, item)
+				if status != StatusPass {
+					continue
+				}
+
+				// Invert the element results like Not
+				childResult := rule.validateRecursive(itemCtx, v)
+				item.Children = []*Result{childResult}
+				switch {
+				case childResult.Status == StatusPass:
+					item.Status = StatusFail
+					item.Message = fmt.Sprintf("element passed %s", rule.Label)
+					item.Code = "none.element_passed"
+					item.Params = Params{"rule": rule.Label}
+				case childResult.Status == StatusError:
+					item.Status = StatusError
+					item.Message = "element errored"
+					item.Code = "none.element_errored"
+				case childResult.Status == StatusTimeout:
+					item.Status = StatusTimeout
+					item.Message = "element timed out"
+					item.Code = "none.element_timed_out"
+				default:
+					item.Status = StatusPass
+				}
+				status = item.Status
+			}
+			return &Result{Status: status, Children: children}
+		},
+	}
+}
+
+// Unique creates a rule that fails for elements of a slice whose key equals
+// the key of an earlier element. Each duplicate is reported as an item node
+// at its index
+func Unique[T any, K comparable](key func(T) K) *Rule[[]T] {
+	return &Rule[[]T]{
+		Label: "unique",
+		Kind:  KindEach,
+		eval: func(ctx context.Context, values []T) *Result {
+			children := []*Result{}
+			first := make(map[K]int, len(values))
+			for i, v := range values {
+				k := key(v)
+				j, seen := first[k]
+				if !seen {
+					first[k] = i
+					continue
+				}
+				_, item := newItem(ctx, strconv.Itoa(i))
+				item.Status = StatusFail
+				item.Message = fmt.Sprintf("duplicate of element %d", j)
+				item.Code = "slice.duplicate"
+				item.Params = Params{"index": j}
+				children = append(children, item)
+			}
+			return reported(children)
+		},
+	}
+}
+
+// SliceLen creates a rule for slice length validation
+func SliceLen[T any](min, max int) *Rule[[]T] {
+	return Test("slice-length", func(ctx context.Context, values []T) error {
+		length := len(values)
+		if length < min {
+			return NewFailure("slice.too_short",
+				fmt.Sprintf("slice too short (min: %d, got: %d)", min, length),
+				Params{"min": min, "got": length})
+		}
+		if length > max {
+			return NewFailure("slice.too_long",
+				fmt.Sprintf("slice too long (max: %d, got: %d)", max, length),
+				Params{"max": max, "got": length})
+		}
+		return nil
+	})
+}
+
+// indexed yields the elements of a slice keyed by index
+func indexed[T any](values []T) iter.Seq2[string, T] {
+	return func(yield func(string, T) bool) {
+		for i, v := range values {
+			if !yield(strconv.Itoa(i), v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package gook
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEach(t *testing.T) {
+	ctx := context.Background()
+	rule := Each(StringContains("@"))
+
+	// Test first failure skips the remaining elements
+	result, ok := rule.Validate(ctx, []string{"a@x", "b", "c"})
+	if ok {
+		t.Error("Expected Each to fail")
+	}
+	if result.Kind != KindEach || result.Label != "each" {
+		t.Errorf("Expected each node, got %v %s", result.Kind, result.Label)
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected element after the failure to be skipped, got: %s", result.Format())
+	}
+
+	// Test CollectAll reports every failing index
+	result, _ = rule.Validate(ctx, []string{"a@x", "b", "c"}, CollectAll())
+	want := map[string][]string{
+		"/1": {"string does not contain @"},
+		"/2": {"string does not contain @"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestSome(t *testing.T) {
+	ctx := context.Background()
+	rule := Some(StringEndsWith("@example.com"))
+
+	result, ok := rule.Validate(ctx, []string{"a@x", "b@example.com", "c@example.com"})
+	if !ok {
+		t.Errorf("Expected Some to pass, got: %s", result.Format())
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected elements after the first pass to be skipped, got: %s", result.Format())
+	}
+
+	result, ok = rule.Validate(ctx, []string{"a@x"})
+	if ok || result.Code != "some.none_passed" {
+		t.Errorf("Expected Some to fail with some.none_passed, got: %s", result.Format())
+	}
+	if _, ok := rule.Validate(ctx, nil); ok {
+		t.Error("Expected Some to fail for an empty slice")
+	}
+
+	// Test a warning element does not count as passed, but makes Some warn
+	soft := Some(WithSeverity(SeverityWarning, StringEndsWith("@example.com")))
+	result, ok = soft.Validate(ctx, []string{"a@x", "b@y"})
+	if !ok || result.Status != StatusWarn || result.Children[1].Status != StatusWarn {
+		t.Errorf("Expected Some to evaluate every element and warn, got: %s", result.Format())
+	}
+}
+
+func TestNone(t *testing.T) {
+	ctx := context.Background()
+	rule := None(StringIs("admin"))
+
+	if result, ok := rule.Validate(ctx, []string{"ada", "bob"}); !ok {
+		t.Errorf("Expected None to pass, got: %s", result.Format())
+	}
+
+	result, ok := rule.Validate(ctx, []string{"ada", "admin", "admin"})
+	if ok {
+		t.Error("Expected None to fail")
+	}
+	want := map[string][]string{"/1": {"element passed string-is"}}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected elements after the first match to be skipped, got: %s", result.Format())
+	}
+
+	// Test a warning element counts as not passing rule
+	soft := None(WithSeverity(SeverityWarning, StringIs("admin")))
+	if result, ok := soft.Validate(ctx, []string{"ada"}); !ok || result.Status != StatusPass {
+		t.Errorf("Expected None to pass over a warning, got: %s", result.Format())
+	}
+
+	// Test a timed out element is not turned into a pass
+	block := make(chan struct{})
+	defer close(block)
+	stuck := WithTimeout(10*time.Millisecond, Test("stuck", func(ctx context.Context, s string) error {
+		<-block
+		return nil
+	}))
+	result, ok = None(stuck).Validate(ctx, []string{"ada", "bob"})
+	if ok || result.Status != StatusTimeout {
+		t.Errorf("Expected None to time out, got: %s", result.Format())
+	}
+	if result.Children[0].Code != "none.element_timed_out" || result.Children[1].Status != StatusSkip {
+		t.Errorf("Expected the timed out element to decide None, got: %s", result.Format())
+	}
+}
+
+func TestUnique(t *testing.T) {
+	ctx := context.Background()
+	rule := Unique(strings.ToLower)
+
+	if _, ok := rule.Validate(ctx, []string{"a", "b", "c"}); !ok {
+		t.Error("Expected distinct elements to pass")
+	}
+
+	result, ok := rule.Validate(ctx, []string{"a", "B", "b", "A"})
+	if ok {
+		t.Error("Expected duplicates to fail")
+	}
+	want := map[string][]string{
+		"/2": {"duplicate of element 1"},
+		"/3": {"duplicate of element 0"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestSliceLen(t *testing.T) {
+	ctx := context.Background()
+	rule := All(SliceLen[int](1, 2), Each(Test("positive", func(ctx context.Context, n int) error {
+		if n <= 0 {
+			return NewFailure("number.not_positive", "number is not positive", nil)
+		}
+		return nil
+	})))
+
+	if _, ok := rule.Validate(ctx, []int{1, 2}); !ok {
+		t.Error("Expected slice within bounds to pass")
+	}
+	if result, _ := rule.Validate(ctx, nil); result.Children[0].Code != "slice.too_short" {
+		t.Errorf("Expected slice.too_short, got: %s", result.Format())
+	}
+	if result, _ := rule.Validate(ctx, []int{1, 2, 3}); result.Children[0].Code != "slice.too_long" {
+		t.Errorf("Expected slice.too_long, got: %s", result.Format())
+	}
+
+	// Test index paths nest under fields
+	field := Field("scores", func(v []int) []int { return v }, rule)
+	result, _ := field.Validate(ctx, []int{1, -1})
+	if msgs := result.Flatten()["/scores/1"]; len(msgs) != 1 {
+		t.Errorf("Expected failure at /scores/1, got %v", result.Flatten())
+	}
+}
@@ -0,0 +1,559 @@
+// Package tags builds validation rules from `gook` struct tags, e.g.
+//
+//	type Signup struct {
+//		Email string   `json:"email" gook:"required,email,len=3..254"`
+//		Age   int      `json:"age" gook:"min=18"`
+//		Tags  []string `json:"tags" gook:"len=..10,dive,len=1..32"`
+//	}
+//
+// Tag rules apply to the field value. Rules after "dive" apply to each element
+// of a slice, array or map instead. Nested structs, pointers to structs and
+// slices, arrays and maps of them are validated recursively. Results are
+// path-aware, using the json name of a field when it has one.
+//
+// To validate without reflection, cmd/gook-gen generates the same rules as Go
+// code from the same tags, built from the typed rules of this package.
+package tags
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/johan-st/gook"
+	"github.com/johan-st/gook/rules"
+)
+
+// Factory creates the rule for a tag on a field of type t
+// arg is the text after "=" in the tag, or "" if there is none
+type Factory func(t reflect.Type, arg string) (*gook.Rule[any], error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"required":   required,
+		"len":        length,
+		"min":        numberMin,
+		"max":        numberMax,
+		"eq":         stringArg(gook.StringIs),
+		"contains":   stringArg(gook.StringContains),
+		"suffix":     stringArg(gook.StringEndsWith),
+		"utf8":       utf8,
+		"email":      stringRule(rules.Email),
+		"url":        stringRule(rules.URL),
+		"uuid":       stringRule(rules.UUID),
+		"phone":      stringRule(rules.PhoneUS),
+		"phone_intl": stringRule(rules.PhoneInternational),
+		"creditcard": stringRule(rules.CreditCard),
+		"ip":         stringRule(rules.IPAddress),
+		"ipv4":       stringRule(rules.IPv4),
+		"ipv6":       stringRule(rules.IPv6),
+		"domain":     stringRule(rules.Domain),
+		"hexcolor":   stringRule(rules.HexColor),
+		"base64":     stringRule(rules.Base64),
+		"json":       stringRule(rules.JSON),
+	}
+)
+
+// Register adds or replaces the factory for a tag name
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+func lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	factory, ok := registry[name]
+	return factory, ok
+}
+
+// Build creates a rule for the struct type T from its `gook` struct tags
+// It fails for unknown tag names and for tags that do not fit the field type
+func Build[T any]() (*gook.Rule[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("tags: %v is not a struct", t)
+	}
+
+	b := &builder{
+		structs:  make(map[reflect.Type]*gook.Rule[any]),
+		building: make(map[reflect.Type]bool),
+	}
+	fields, err := buildFields(b, t, func(v T) reflect.Value {
+		return reflect.ValueOf(v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gook.Object(t.Name(), fields...), nil
+}
+
+// MustBuild is like Build but panics if the tags are invalid
+func MustBuild[T any]() *gook.Rule[T] {
+	rule, err := Build[T]()
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// builder caches struct rules so recursive types refer to the same rule
+type builder struct {
+	structs  map[reflect.Type]*gook.Rule[any]
+	building map[reflect.Type]bool
+}
+
+// empty returns true if values of struct type t have nothing to validate
+func (b *builder) empty(t reflect.Type) bool {
+	return !b.building[t] && len(b.structs[t].Children) == 0
+}
+
+// structRule returns the rule for values of struct type t
+func (b *builder) structRule(t reflect.Type) (*gook.Rule[any], error) {
+	if rule, ok := b.structs[t]; ok {
+		return rule, nil
+	}
+
+	// Register before building so recursive fields find it
+	rule := &gook.Rule[any]{}
+	b.structs[t] = rule
+	b.building[t] = true
+	defer delete(b.building, t)
+	fields, err := buildFields(b, t, func(v any) reflect.Value {
+		return reflect.ValueOf(v)
+	})
+	if err != nil {
+		return nil, err
+	}
+	*rule = *gook.Object(t.Name(), fields...)
+	return rule, nil
+}
+
+// buildFields creates the field rules of struct type t for values of type S
+func buildFields[S any](b *builder, t reflect.Type, value func(S) reflect.Value) ([]*gook.Rule[S], error) {
+	var fields []*gook.Rule[S]
+	for _, field := range reflect.VisibleFields(t) {
+		tag, tagged := field.Tag.Lookup("gook")
+		if tag == "-" || !field.IsExported() || (field.Anonymous && !tagged) {
+			continue
+		}
+
+		get := func(v S) any {
+			f, err := value(v).FieldByIndexErr(field.Index)
+			if err != nil {
+				// Promoted through a nil embedded pointer
+				return unreachable{}
+			}
+			return f.Interface()
+		}
+
+		rule, err := b.valueRule(field.Type, tag)
+		if err != nil {
+			return nil, fmt.Errorf("tags: %v.%s: %w", t, field.Name, err)
+		}
+		if rule == nil {
+			continue
+		}
+		if throughPointer(t, field.Index) {
+			// Like fields behind a pointer, skip it when the pointer is nil
+			rule = gook.Elements("optional", reachable, rule)
+		}
+		fields = append(fields, gook.Field(fieldName(field), get, rule))
+	}
+	return fields, nil
+}
+
+// valueRule creates the rule for a value of type t with the given tag, or nil
+// if there is nothing to validate
+func (b *builder) valueRule(t reflect.Type, tag string) (*gook.Rule[any], error) {
+	own, dive, hasDive := splitDive(tag)
+	checks, err := tagRules(t, own)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case t.Kind() == reflect.Struct:
+		nested, err := b.structRule(t)
+		if err != nil {
+			return nil, err
+		}
+		if !b.empty(t) {
+			checks = append(checks, nested)
+		}
+
+	case t.Kind() == reflect.Pointer && t.Elem().Kind() == reflect.Struct:
+		nested, err := b.structRule(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if !b.empty(t.Elem()) {
+			checks = append(checks, gook.Elements("optional", present, nested))
+		}
+
+	case isCollection(t) && t.Elem().Kind() != reflect.Uint8:
+		elem, err := b.valueRule(t.Elem(), dive)
+		if err != nil {
+			return nil, err
+		}
+		if elem != nil {
+			checks = append(checks, gook.Elements("elements", items, elem))
+		}
+
+	case hasDive:
+		return nil, fmt.Errorf("dive on %v, which is not a slice, array or map", t)
+	}
+
+	switch len(checks) {
+	case 0:
+		return nil, nil
+	case 1:
+		return checks[0], nil
+	default:
+		return gook.All(checks...), nil
+	}
+}
+
+// tagRules creates the rules for the comma separated tag names in tag
+func tagRules(t reflect.Type, tag string) ([]*gook.Rule[any], error) {
+	var checks []*gook.Rule[any]
+	for _, part := range strings.Split(tag, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, arg, _ := strings.Cut(part, "=")
+		factory, ok := lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown tag %q", name)
+		}
+		rule, err := factory(t, arg)
+		if err != nil {
+			return nil, fmt.Errorf("tag %q: %w", part, err)
+		}
+		checks = append(checks, rule)
+	}
+	return checks, nil
+}
+
+// splitDive splits tag at its first "dive" into the tags of a value and the
+// tags of its elements
+func splitDive(tag string) (string, string, bool) {
+	parts := strings.Split(tag, ",")
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "dive" {
+			return strings.Join(parts[:i], ","), strings.Join(parts[i+1:], ","), true
+		}
+	}
+	return tag, "", false
+}
+
+// fieldName returns the json name of a field, or its Go name
+func fieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func isCollection(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// unreachable is the value of a field promoted through a nil embedded pointer
+type unreachable struct{}
+
+// throughPointer returns true if the field of struct type t at index is
+// promoted through an embedded pointer
+func throughPointer(t reflect.Type, index []int) bool {
+	for _, i := range index[:len(index)-1] {
+		t = t.Field(i).Type
+		if t.Kind() == reflect.Pointer {
+			return true
+		}
+	}
+	return false
+}
+
+// reachable yields the value of a promoted field unless it is unreachable
+func reachable(v any) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		if _, ok := v.(unreachable); !ok {
+			yield("", v)
+		}
+	}
+}
+
+// present yields the struct behind a non-nil pointer at the pointer's path
+func present(v any) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		rv := reflect.ValueOf(v)
+		if rv.IsValid() && !rv.IsNil() {
+			yield("", rv.Elem().Interface())
+		}
+	}
+}
+
+// items yields the elements of a slice or array by index, or the values of a
+// map by key in sorted key order
+func items(v any) iter.Seq2[string, any] {
+	return func(yield func(string, any) bool) {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Map {
+			for i := range rv.Len() {
+				if !yield(strconv.Itoa(i), rv.Index(i).Interface()) {
+					return
+				}
+			}
+			return
+		}
+
+		// Entries are collected rather than looked up, as a NaN key finds nothing
+		entries := make([][2]reflect.Value, 0, rv.Len())
+		for iter := rv.MapRange(); iter.Next(); {
+			entries = append(entries, [2]reflect.Value{iter.Key(), iter.Value()})
+		}
+		slices.SortStableFunc(entries, func(a, b [2]reflect.Value) int {
+			return compareKeys(a[0], b[0])
+		})
+		for _, entry := range entries {
+			if !yield(fmt.Sprint(entry[0].Interface()), entry[1].Interface()) {
+				return
+			}
+		}
+	}
+}
+
+// compareKeys orders map keys naturally: numbers by value, strings
+// lexically, false before true, structs and arrays element by element, and
+// interfaces by the name of their dynamic type, then by value
+func compareKeys(a, b reflect.Value) int {
+	if a.Kind() != b.Kind() {
+		return cmp.Compare(a.Kind(), b.Kind())
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		if c := cmp.Compare(real(a.Complex()), real(b.Complex())); c != 0 {
+			return c
+		}
+		return cmp.Compare(imag(a.Complex()), imag(b.Complex()))
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		return cmp.Compare(boolRank(a.Bool()), boolRank(b.Bool()))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if c := compareKeys(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Array:
+		for i := range a.Len() {
+			if c := compareKeys(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolRank(!a.IsNil()), boolRank(!b.IsNil()))
+		}
+		if c := cmp.Compare(a.Elem().Type().String(), b.Elem().Type().String()); c != 0 {
+			return c
+		}
+		return compareKeys(a.Elem(), b.Elem())
+	}
+	return 0
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// adapt turns a typed rule into a rule on any by converting the value
+// Test rules keep their label so the trace reads the same as for typed rules
+func adapt[F any](rule *gook.Rule[F], convert func(reflect.Value) F) *gook.Rule[any] {
+	if rule.Kind == gook.KindTest {
+		return gook.Test(rule.Label, func(ctx context.Context, v any) error {
+			return rule.TestFn(ctx, convert(reflect.ValueOf(v)))
+		})
+	}
+	return gook.As(func(v any) (F, error) {
+		return convert(reflect.ValueOf(v)), nil
+	}, rule)
+}
+
+func toString(v reflect.Value) string { return v.String() }
+
+func toBytes(v reflect.Value) []byte { return v.Bytes() }
+
+func isString(t reflect.Type) bool { return t.Kind() == reflect.String }
+
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// stringRule creates a factory for an argument-less rule on strings
+func stringRule(rule func() *gook.Rule[string]) Factory {
+	return func(t reflect.Type, arg string) (*gook.Rule[any], error) {
+		if !isString(t) {
+			return nil, fmt.Errorf("needs a string field, got %v", t)
+		}
+		if arg != "" {
+			return nil, fmt.Errorf("takes no argument")
+		}
+		return adapt(rule(), toString), nil
+	}
+}
+
+// stringArg creates a factory for a rule on strings taking a string argument
+func stringArg(rule func(string) *gook.Rule[string]) Factory {
+	return func(t reflect.Type, arg string) (*gook.Rule[any], error) {
+		if !isString(t) {
+			return nil, fmt.Errorf("needs a string field, got %v", t)
+		}
+		return adapt(rule(arg), toString), nil
+	}
+}
+
+func required(t reflect.Type, arg string) (*gook.Rule[any], error) {
+	return gook.Test("required", func(ctx context.Context, v any) error {
+		if v == nil || reflect.ValueOf(v).IsZero() {
+			return requiredFailure()
+		}
+		return nil
+	}), nil
+}
+
+func utf8(t reflect.Type, arg string) (*gook.Rule[any], error) {
+	rule := gook.BytesEncoding(gook.EncodingUTF8)
+	switch {
+	case isBytes(t):
+		return adapt(rule, toBytes), nil
+	case isString(t):
+		return adapt(rule, func(v reflect.Value) []byte { return []byte(v.String()) }), nil
+	}
+	return nil, fmt.Errorf("needs a string or []byte field, got %v", t)
+}
+
+// length handles len=n, len=min..max, len=min.. and len=..max
+func length(t reflect.Type, arg string) (*gook.Rule[any], error) {
+	min, max, err := parseRange(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case isString(t):
+		return adapt(gook.StringLength(min, max), toString), nil
+	case isBytes(t):
+		return adapt(gook.All(gook.BytesMin(min), gook.BytesMax(max)), toBytes), nil
+	case isCollection(t):
+		return gook.Test("length", func(ctx context.Context, v any) error {
+			return checkLength(min, max, reflect.ValueOf(v).Len())
+		}), nil
+	}
+	return nil, fmt.Errorf("needs a string, slice, array or map field, got %v", t)
+}
+
+func parseRange(arg string) (int, int, error) {
+	lo, hi, isRange := strings.Cut(arg, "..")
+	if !isRange {
+		hi = lo
+	}
+
+	min, max := 0, int(^uint(0)>>1)
+	var err error
+	if lo != "" {
+		if min, err = strconv.Atoi(lo); err != nil {
+			return 0, 0, fmt.Errorf("invalid length %q", arg)
+		}
+	}
+	if hi != "" {
+		if max, err = strconv.Atoi(hi); err != nil {
+			return 0, 0, fmt.Errorf("invalid length %q", arg)
+		}
+	}
+	if lo == "" && hi == "" {
+		return 0, 0, fmt.Errorf("missing length")
+	}
+	return min, max, nil
+}
+
+func numberMin(t reflect.Type, arg string) (*gook.Rule[any], error) {
+	return numberBound(t, arg, "min", func(c int) bool { return c >= 0 })
+}
+
+func numberMax(t reflect.Type, arg string) (*gook.Rule[any], error) {
+	return numberBound(t, arg, "max", func(c int) bool { return c <= 0 })
+}
+
+// numberBound creates a rule comparing a numeric field to the bound in arg,
+// parsed for the kind of t. ok reports whether the comparison of the field
+// with the bound is accepted. Values are compared in their own kind so large
+// integers keep their precision, as in Min and Max
+func numberBound(t reflect.Type, arg, name string, ok func(c int) bool) (*gook.Rule[any], error) {
+	var (
+		bound   reflect.Value
+		compare func(v, bound reflect.Value) int
+		err     error
+	)
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(arg, 10, t.Bits())
+		bound = reflect.ValueOf(n)
+		compare = func(v, bound reflect.Value) int { return cmp.Compare(v.Int(), bound.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var n uint64
+		n, err = strconv.ParseUint(arg, 10, t.Bits())
+		bound = reflect.ValueOf(n)
+		compare = func(v, bound reflect.Value) int { return cmp.Compare(v.Uint(), bound.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		f, err = strconv.ParseFloat(arg, t.Bits())
+		if err == nil && (math.IsInf(f, 0) || math.IsNaN(f)) {
+			err = strconv.ErrRange
+		}
+		bound = reflect.ValueOf(f)
+		compare = func(v, bound reflect.Value) int { return cmp.Compare(v.Float(), bound.Float()) }
+	default:
+		return nil, fmt.Errorf("needs a numeric field, got %v", t)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v bound %q", t.Kind(), arg)
+	}
+
+	// Report the bound in the field type, like the typed rules
+	bound = bound.Convert(t)
+	return gook.Test(name, func(ctx context.Context, v any) error {
+		if !ok(compare(reflect.ValueOf(v), bound)) {
+			return boundFailure(name, bound.Interface(), v)
+		}
+		return nil
+	}), nil
+}
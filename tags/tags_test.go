@@ -0,0 +1,261 @@
+package tags
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/johan-st/gook"
+)
+
+type address struct {
+	City string `json:"city" gook:"required"`
+	Zip  string `json:"zip" gook:"len=5"`
+}
+
+type signup struct {
+	Email    string            `json:"email" gook:"required,email,len=3..254"`
+	Age      int               `json:"age" gook:"min=18,max=130"`
+	Tags     []string          `json:"tags" gook:"len=..3,dive,len=1..8"`
+	Home     address           `json:"home"`
+	Work     *address          `json:"work"`
+	Previous []address         `json:"previous"`
+	Labels   map[string]string `json:"labels" gook:"dive,hexcolor"`
+	internal string
+}
+
+func validSignup() signup {
+	return signup{
+		Email:  "ada@example.com",
+		Age:    36,
+		Tags:   []string{"go"},
+		Home:   address{City: "Lund", Zip: "22100"},
+		Labels: map[string]string{"bg": "#ffffff"},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	ctx := context.Background()
+	rule, err := Build[signup]()
+	if err != nil {
+		t.Fatalf("Expected tags to build, got %v", err)
+	}
+
+	// Test valid value
+	result, ok := rule.Validate(ctx, validSignup())
+	if !ok {
+		t.Errorf("Expected valid signup to pass, got: %s", result.Format())
+	}
+
+	// Test failures are reported at their paths
+	invalid := validSignup()
+	invalid.Email = "nope"
+	invalid.Age = 12
+	invalid.Tags = []string{"go", "much-too-long"}
+	invalid.Home.Zip = "221"
+	invalid.Work = &address{Zip: "22100"}
+	invalid.Previous = []address{{City: "Malmö", Zip: "21100"}, {City: "Ystad", Zip: "1"}}
+	invalid.Labels = map[string]string{"bg": "#fff", "fg": "red"}
+
+	result, ok = rule.Validate(ctx, invalid, gook.CollectAll())
+	if ok {
+		t.Error("Expected invalid signup to fail")
+	}
+	want := map[string][]string{
+		"/email":          {"invalid email format"},
+		"/age":            {"number too small (min: 18, got: 12)"},
+		"/tags/1":         {"string too long (max: 8, got: 13)"},
+		"/home/zip":       {"string too short (min: 5, got: 3)"},
+		"/work/city":      {"value is required"},
+		"/previous/1/zip": {"string too short (min: 5, got: 1)"},
+		"/labels/fg":      {"invalid hex color format (expected #RRGGBB, #RGB, or #RRGGBBAA)"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test nil pointers to structs are not validated
+	valid := validSignup()
+	valid.Work = nil
+	if _, ok := rule.Validate(ctx, valid); !ok {
+		t.Error("Expected nil nested struct to pass")
+	}
+}
+
+type node struct {
+	Name     string  `json:"name" gook:"required"`
+	Children []*node `json:"children"`
+}
+
+func TestBuildRecursive(t *testing.T) {
+	rule := MustBuild[node]()
+
+	tree := node{Name: "root", Children: []*node{
+		{Name: "a"},
+		{Name: "b", Children: []*node{{Name: ""}}},
+	}}
+	result, ok := rule.Validate(context.Background(), tree)
+	if ok {
+		t.Error("Expected tree with unnamed node to fail")
+	}
+	if _, found := result.Flatten()["/children/1/children/0/name"]; !found {
+		t.Errorf("Expected failure deep in the tree, got %v", result.Flatten())
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	type unknownTag struct {
+		Name string `gook:"required,shiny"`
+	}
+	type wrongType struct {
+		Count int `gook:"email"`
+	}
+	type badDive struct {
+		Name string `gook:"dive,email"`
+	}
+
+	if _, err := Build[unknownTag](); err == nil || !strings.Contains(err.Error(), `unknown tag "shiny"`) {
+		t.Errorf("Expected unknown tag error, got %v", err)
+	}
+	if _, err := Build[wrongType](); err == nil || !strings.Contains(err.Error(), "needs a string field") {
+		t.Errorf("Expected type error, got %v", err)
+	}
+	if _, err := Build[badDive](); err == nil || !strings.Contains(err.Error(), "dive") {
+		t.Errorf("Expected dive error, got %v", err)
+	}
+	if _, err := Build[string](); err == nil {
+		t.Error("Expected non-struct type to be rejected")
+	}
+}
+
+func TestBuildBounds(t *testing.T) {
+	ctx := context.Background()
+
+	// Test large integers are compared without losing precision
+	type large struct {
+		ID    int64  `gook:"max=9007199254740992"`
+		Count uint64 `gook:"min=18446744073709551615"`
+	}
+	rule := MustBuild[large]()
+	result, ok := rule.Validate(ctx, large{ID: 9007199254740993, Count: 18446744073709551614}, gook.CollectAll())
+	if ok {
+		t.Error("Expected values just past the bounds to fail")
+	}
+	want := map[string][]string{
+		"/ID":    {"number too large (max: 9007199254740992, got: 9007199254740993)"},
+		"/Count": {"number too small (min: 18446744073709551615, got: 18446744073709551614)"},
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+
+	// Test the bound must fit the field type
+	type fractional struct {
+		N int `gook:"min=1.5"`
+	}
+	if _, err := Build[fractional](); err == nil || !strings.Contains(err.Error(), `invalid int bound "1.5"`) {
+		t.Errorf("Expected bound error, got %v", err)
+	}
+	type overflow struct {
+		N int8 `gook:"max=300"`
+	}
+	if _, err := Build[overflow](); err == nil || !strings.Contains(err.Error(), `invalid int8 bound "300"`) {
+		t.Errorf("Expected bound error, got %v", err)
+	}
+}
+
+func TestBuildMapOrder(t *testing.T) {
+	ctx := context.Background()
+
+	// Test map values are visited in the natural order of their keys
+	type scores struct {
+		ByLevel map[int]string `gook:"dive,required"`
+	}
+	result, _ := MustBuild[scores]().Validate(ctx, scores{ByLevel: map[int]string{10: "", 2: "", 1: "ok"}}, gook.CollectAll())
+	elements := result.Children[0].Children[0]
+	var keys []string
+	for _, item := range elements.Children {
+		keys = append(keys, item.Path)
+	}
+	if want := []string{"/ByLevel/1", "/ByLevel/2", "/ByLevel/10"}; !reflect.DeepEqual(keys, want) {
+		t.Errorf("Expected keys in order %v, got %v", want, keys)
+	}
+
+	// Test keys printing the same are still validated one by one
+	type mixed struct {
+		Values map[any]string `gook:"dive,required"`
+	}
+	result, _ = MustBuild[mixed]().Validate(ctx, mixed{Values: map[any]string{1: "", "1": ""}}, gook.CollectAll())
+	if msgs := result.Flatten()["/Values/1"]; len(msgs) != 2 {
+		t.Errorf("Expected both values to fail, got %v", result.Flatten())
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("even", func(typ reflect.Type, arg string) (*gook.Rule[any], error) {
+		if typ.Kind() != reflect.Int {
+			return nil, errors.New("needs an int field")
+		}
+		return gook.Test("even", func(ctx context.Context, v any) error {
+			if v.(int)%2 != 0 {
+				return errors.New("number is odd")
+			}
+			return nil
+		}), nil
+	})
+
+	type counter struct {
+		Count int `json:"count" gook:"even"`
+	}
+	rule := MustBuild[counter]()
+	result, ok := rule.Validate(context.Background(), counter{Count: 3})
+	if ok {
+		t.Error("Expected odd count to fail")
+	}
+	if msgs := result.Flatten()["/count"]; len(msgs) != 1 || msgs[0] != "number is odd" {
+		t.Errorf("Expected registered rule failure, got %v", result.Flatten())
+	}
+
+	// Test a tag name starting with "dive" is not taken for dive
+	Register("diverse", func(typ reflect.Type, arg string) (*gook.Rule[any], error) {
+		return gook.Test("diverse", func(ctx context.Context, v any) error { return nil }), nil
+	})
+	type named struct {
+		Name  string   `gook:"required,diverse"`
+		Names []string `gook:"diverse,dive,diverse"`
+	}
+	if _, err := Build[named](); err != nil {
+		t.Errorf("Expected tag diverse to build, got %v", err)
+	}
+}
+
+type base struct {
+	Age int `json:"age" gook:"min=18"`
+}
+
+type member struct {
+	*base
+	Name string `json:"name" gook:"required"`
+}
+
+func TestBuildNilEmbeddedPointer(t *testing.T) {
+	ctx := context.Background()
+	rule := MustBuild[member]()
+
+	// Test fields promoted through a nil pointer are skipped
+	result, ok := rule.Validate(ctx, member{Name: "ada"})
+	if !ok {
+		t.Errorf("Expected nil embedded pointer to pass, got: %s", result.Format())
+	}
+
+	// Test they are validated when the pointer is set
+	result, ok = rule.Validate(ctx, member{base: &base{Age: 12}, Name: "ada"})
+	if ok {
+		t.Error("Expected promoted field to be validated")
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/age": {"number too small (min: 18, got: 12)"}}) {
+		t.Errorf("Expected failure at /age, got %v", got)
+	}
+}
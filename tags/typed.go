@@ -0,0 +1,185 @@
+package tags
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+
+	"github.com/johan-st/gook"
+)
+
+// The typed rules below are the reflection-free counterparts of the built-in
+// tags. They fail with the same codes and messages as Build and are what
+// gook-gen emits for generated struct rules.
+
+// Number is the set of types the min and max tags apply to
+type Number = gook.Number
+
+// Required fails for the zero value of T
+func Required[T comparable]() *gook.Rule[T] {
+	return gook.Test("required", func(ctx context.Context, v T) error {
+		var zero T
+		if v == zero {
+			return requiredFailure()
+		}
+		return nil
+	})
+}
+
+// RequiredSlice fails for nil slices, like Required for other types
+func RequiredSlice[S ~[]E, E any]() *gook.Rule[S] {
+	return gook.Test("required", func(ctx context.Context, v S) error {
+		if v == nil {
+			return requiredFailure()
+		}
+		return nil
+	})
+}
+
+// RequiredMap fails for nil maps, like Required for other types
+func RequiredMap[M ~map[K]V, K comparable, V any]() *gook.Rule[M] {
+	return gook.Test("required", func(ctx context.Context, v M) error {
+		if v == nil {
+			return requiredFailure()
+		}
+		return nil
+	})
+}
+
+// Min fails for numbers below min
+func Min[T Number](min T) *gook.Rule[T] {
+	return gook.Test("min", func(ctx context.Context, v T) error {
+		if v < min {
+			return boundFailure("min", min, v)
+		}
+		return nil
+	})
+}
+
+// Max fails for numbers above max
+func Max[T Number](max T) *gook.Rule[T] {
+	return gook.Test("max", func(ctx context.Context, v T) error {
+		if v > max {
+			return boundFailure("max", max, v)
+		}
+		return nil
+	})
+}
+
+// Len fails for slices with fewer than min or more than max elements
+func Len[S ~[]E, E any](min, max int) *gook.Rule[S] {
+	return gook.Test("length", func(ctx context.Context, v S) error {
+		return checkLength(min, max, len(v))
+	})
+}
+
+// MapLen fails for maps with fewer than min or more than max entries
+func MapLen[M ~map[K]V, K comparable, V any](min, max int) *gook.Rule[M] {
+	return gook.Test("length", func(ctx context.Context, v M) error {
+		return checkLength(min, max, len(v))
+	})
+}
+
+// UTF8 fails for strings that are not valid UTF-8
+func UTF8() *gook.Rule[string] {
+	return convert(gook.BytesEncoding(gook.EncodingUTF8), func(s string) []byte {
+		return []byte(s)
+	})
+}
+
+// String applies a rule on strings to a named string type
+func String[F ~string](rule *gook.Rule[string]) *gook.Rule[F] {
+	return convert(rule, func(v F) string { return string(v) })
+}
+
+// Bytes applies a rule on byte slices to a named byte slice type
+func Bytes[F ~[]byte](rule *gook.Rule[[]byte]) *gook.Rule[F] {
+	return convert(rule, func(v F) []byte { return []byte(v) })
+}
+
+// convert applies rule to values of type F through to
+// Test rules keep their label so the trace reads the same as for the original
+func convert[F, T any](rule *gook.Rule[T], to func(F) T) *gook.Rule[F] {
+	if rule.Kind == gook.KindTest {
+		return gook.Test(rule.Label, func(ctx context.Context, v F) error {
+			return rule.TestFn(ctx, to(v))
+		})
+	}
+	return gook.Elements(rule.Label, func(v F) iter.Seq2[string, T] {
+		return func(yield func(string, T) bool) {
+			yield("", to(v))
+		}
+	}, rule)
+}
+
+// Indexed yields the elements of a slice keyed by index
+func Indexed[S ~[]E, E any](s S) iter.Seq2[string, E] {
+	return func(yield func(string, E) bool) {
+		for i, v := range s {
+			if !yield(strconv.Itoa(i), v) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted yields the values of a map keyed by key, in sorted key order
+// Like Build, it orders the keys themselves, so 2 comes before 10
+func Sorted[M ~map[K]V, K cmp.Ordered, V any](m M) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		// Entries are collected rather than looked up, as a NaN key finds nothing
+		type entry struct {
+			key   K
+			value V
+		}
+		entries := make([]entry, 0, len(m))
+		for k, v := range m {
+			entries = append(entries, entry{k, v})
+		}
+		slices.SortStableFunc(entries, func(a, b entry) int {
+			return cmp.Compare(a.key, b.key)
+		})
+		for _, e := range entries {
+			if !yield(fmt.Sprint(e.key), e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Present yields the value behind a non-nil pointer at the pointer's path
+func Present[T any](p *T) iter.Seq2[string, T] {
+	return func(yield func(string, T) bool) {
+		if p != nil {
+			yield("", *p)
+		}
+	}
+}
+
+func requiredFailure() error {
+	return gook.NewFailure("value.required", "value is required", nil)
+}
+
+func checkLength(min, max, n int) error {
+	if n < min || n > max {
+		return gook.NewFailure("length.out_of_range",
+			fmt.Sprintf("length out of range (min: %d, max: %d, got: %d)", min, max, n),
+			gook.Params{"min": min, "max": max, "got": n})
+	}
+	return nil
+}
+
+// boundFailure reports a number outside the min or max bound
+func boundFailure(name string, bound, got any) error {
+	if name == "min" {
+		return gook.NewFailure("number.too_small",
+			fmt.Sprintf("number too small (min: %v, got: %v)", bound, got),
+			gook.Params{name: bound, "got": got})
+	}
+	return gook.NewFailure("number.too_large",
+		fmt.Sprintf("number too large (max: %v, got: %v)", bound, got),
+		gook.Params{name: bound, "got": got})
+}
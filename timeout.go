@@ -0,0 +1,107 @@
+package gook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is the context cause used when a rule timeout or a validation
+// budget runs out
+type TimeoutError struct {
+	Timeout time.Duration
+	Budget  bool // the whole-tree budget ran out rather than a rule timeout
+}
+
+// Error returns a description of the overrun
+func (e *TimeoutError) Error() string {
+	if e.Budget {
+		return fmt.Sprintf("validation budget exceeded (%v)", e.Timeout)
+	}
+	return fmt.Sprintf("timed out after %v", e.Timeout)
+}
+
+func (e *TimeoutError) code() string {
+	if e.Budget {
+		return "timeout.budget"
+	}
+	return "timeout.rule"
+}
+
+func (e *TimeoutError) params() Params {
+	return Params{"timeout": e.Timeout}
+}
+
+// WithTimeout returns a copy of rule that times out after d
+// A running TestFn is abandoned when its timeout runs out; it should still
+// honour ctx.Done() so its goroutine does not outlive the validation
+func WithTimeout[T any](d time.Duration, rule *Rule[T]) *Rule[T] {
+	timed := *rule
+	timed.Timeout = d
+	return &timed
+}
+
+// validateWithTimeout evaluates the rule under its own deadline and reports an
+// overrun as StatusTimeout
+func (r *Rule[T]) validateWithTimeout(ctx context.Context, value T) *Result {
+	cause := &TimeoutError{Timeout: r.Timeout}
+	ctx, cancel := context.WithTimeoutCause(interruptible(ctx), r.Timeout, cause)
+	defer cancel()
+
+	result := r.evaluate(ctx, value)
+	if result.failed() && context.Cause(ctx) == cause {
+		result.Status = StatusTimeout
+		result.Message = cause.Error()
+		result.Code = cause.code()
+		result.Params = cause.params()
+		result.Cause = cause
+	}
+	return result
+}
+
+// runTestInterruptible runs TestFn in its own goroutine so that a rule timeout
+// or validation budget can abandon it
+func (r *Rule[T]) runTestInterruptible(ctx context.Context, value T) (error, *PanicError) {
+	type outcome struct {
+		err      error
+		panicErr *PanicError
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		err, panicErr := r.runTest(ctx, value)
+		done <- outcome{err, panicErr}
+	}()
+
+	select {
+	case out := <-done:
+		return out.err, out.panicErr
+	case <-ctx.Done():
+		return ctx.Err(), nil
+	}
+}
+
+// cancelledResult returns the result for a rule whose context is done
+// Overruns of rule timeouts and budgets are reported as StatusTimeout
+func (r *Rule[T]) cancelledResult(ctx context.Context) *Result {
+	var timeoutErr *TimeoutError
+	if errors.As(context.Cause(ctx), &timeoutErr) {
+		return &Result{
+			Status:  StatusTimeout,
+			Label:   r.Label,
+			Kind:    r.Kind,
+			Message: timeoutErr.Error(),
+			Code:    timeoutErr.code(),
+			Params:  timeoutErr.params(),
+			Cause:   timeoutErr,
+		}
+	}
+	return &Result{
+		Status:  StatusFail,
+		Label:   r.Label,
+		Kind:    r.Kind,
+		Message: "context cancelled",
+		Code:    "context.cancelled",
+		Cause:   context.Cause(ctx),
+	}
+}
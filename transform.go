@@ -0,0 +1,63 @@
+package gook
+
+import (
+	"context"
+	"fmt"
+)
+
+// Map creates a rule that converts a value of type A to B with fn and
+// validates the converted value against rule. The conversion shows up as its
+// own node labeled name, so a failing conversion is told apart from a failing
+// rule; if fn returns an error, rule is reported as skipped
+func Map[A, B any](name string, fn func(context.Context, A) (B, error), rule *Rule[B]) *Rule[A] {
+	return &Rule[A]{
+		Label: name,
+		Kind:  KindMap,
+		eval: func(ctx context.Context, value A) *Result {
+			converted, err := fn(ctx, value)
+			if err != nil {
+				return transformFailed(err, "map.transform_failed", skipped(rule))
+			}
+			return wrap(rule.validateRecursive(ctx, converted))
+		},
+	}
+}
+
+// Transform turns a rule on B into a rule on A, see Step and Pipe
+type Transform[A, B any] func(rule *Rule[B]) *Rule[A]
+
+// Step creates the Transform that applies Map with name and fn
+func Step[A, B any](name string, fn func(context.Context, A) (B, error)) Transform[A, B] {
+	return func(rule *Rule[B]) *Rule[A] {
+		return Map(name, fn, rule)
+	}
+}
+
+// Pipe chains two transforms, running first and then second
+// Nest Pipe to chain more steps, e.g.
+//
+//	Pipe(Pipe(Step("trim", trim), Step("parse", parse)), Step("local", local))(rule)
+func Pipe[A, B, C any](first Transform[A, B], second Transform[B, C]) Transform[A, C] {
+	return func(rule *Rule[C]) *Rule[A] {
+		return first(second(rule))
+	}
+}
+
+// transformFailed returns the result of a conversion that failed with err
+// The remaining rule that could not run is reported as skipped
+func transformFailed(err error, code string, rest *Result) *Result {
+	transform := &Result{
+		Status:  StatusFail,
+		Label:   "transform",
+		Kind:    KindTest,
+		Message: err.Error(),
+		Cause:   err,
+	}
+	transform.setFailure(err)
+	return &Result{
+		Status:   StatusFail,
+		Message:  fmt.Sprintf("transform failed: %v", err),
+		Code:     code,
+		Children: []*Result{transform, rest},
+	}
+}
@@ -0,0 +1,54 @@
+package gook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMapPipe(t *testing.T) {
+	ctx := context.Background()
+	trim := Step("trim", func(ctx context.Context, s string) (string, error) {
+		return strings.TrimSpace(s), nil
+	})
+	parse := Step("parse-time", func(ctx context.Context, s string) (time.Time, error) {
+		return time.Parse(time.Kitchen, s)
+	})
+	businessHours := Test("business-hours", func(ctx context.Context, t time.Time) error {
+		if t.Hour() < 9 || t.Hour() >= 17 {
+			return NewFailure("time.outside_hours", "time is outside business hours", nil)
+		}
+		return nil
+	})
+	rule := Pipe(trim, parse)(businessHours)
+
+	if result, ok := rule.Validate(ctx, "  10:30AM "); !ok {
+		t.Errorf("Expected time within business hours to pass, got: %s", result.Format())
+	}
+
+	// Test each step is its own node
+	result, ok := rule.Validate(ctx, " 8:00PM")
+	if ok {
+		t.Error("Expected time outside business hours to fail")
+	}
+	parseNode := result.Children[0]
+	if result.Kind != KindMap || result.Label != "trim" || parseNode.Kind != KindMap || parseNode.Label != "parse-time" {
+		t.Fatalf("Expected trim and parse-time nodes, got: %s", result.Format())
+	}
+	if leaf := parseNode.Children[0]; leaf.Label != "business-hours" || leaf.Code != "time.outside_hours" {
+		t.Errorf("Expected business-hours failure, got: %s", result.Format())
+	}
+
+	// Test a failing conversion skips the rest of the chain
+	result, _ = rule.Validate(ctx, "noon")
+	parseNode = result.Children[0]
+	if parseNode.Code != "map.transform_failed" || parseNode.Children[1].Status != StatusSkip {
+		t.Errorf("Expected failed parse-time conversion, got: %s", result.Format())
+	}
+	var parseErr *time.ParseError
+	if !errors.As(result.Err(), &parseErr) {
+		t.Errorf("Expected the parse error to be exposed, got %v", result.Err())
+	}
+}
@@ -0,0 +1,50 @@
+package gook
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Union creates a rule for a tagged union that reads the discriminator of a
+// value with key and validates it against the branch registered for it
+// Only the chosen branch appears in the result. A discriminator without a
+// branch fails, listing the accepted values; name labels the discriminator
+func Union[T any, D cmp.Ordered](name string, key func(T) D, branches map[D]*Rule[T]) *Rule[T] {
+	accepted := slices.Sorted(maps.Keys(branches))
+	names := make([]string, len(accepted))
+	for i, d := range accepted {
+		names[i] = fmt.Sprint(d)
+	}
+
+	return &Rule[T]{
+		Label: name,
+		Kind:  KindUnion,
+		eval: func(ctx context.Context, value T) *Result {
+			d := key(value)
+			branch, ok := branches[d]
+			if !ok {
+				return &Result{
+					Status:  StatusFail,
+					Message: fmt.Sprintf("unknown %s %v (accepted: %s)", name, d, strings.Join(names, ", ")),
+					Code:    "union.unknown",
+					Params:  Params{"name": name, "got": d, "accepted": names},
+				}
+			}
+			return wrap(branch.validateRecursive(ctx, value))
+		},
+	}
+}
+
+// JSONUnion creates a Union for objects decoded from JSON, discriminated by
+// the string property name. A missing or non-string property is unknown
+func JSONUnion(name string, branches map[string]*Rule[any]) *Rule[any] {
+	return Union(name, func(value any) string {
+		obj, _ := value.(map[string]any)
+		d, _ := obj[name].(string)
+		return d
+	}, branches)
+}
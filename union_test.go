@@ -0,0 +1,71 @@
+package gook
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type testEvent struct {
+	Type  string
+	Email string
+	Count int
+}
+
+func TestUnion(t *testing.T) {
+	ctx := context.Background()
+	rule := Union("type", func(e testEvent) string { return e.Type }, map[string]*Rule[testEvent]{
+		"signup": Object("signup",
+			Field("email", func(e testEvent) string { return e.Email }, StringContains("@")),
+		),
+		"batch": Object("batch",
+			Field("count", func(e testEvent) int { return e.Count }, Test("positive", func(ctx context.Context, n int) error {
+				if n <= 0 {
+					return NewFailure("number.not_positive", "number is not positive", nil)
+				}
+				return nil
+			})),
+		),
+	})
+
+	// Test only the chosen branch is recorded
+	result, ok := rule.Validate(ctx, testEvent{Type: "signup", Email: "ada"})
+	if ok {
+		t.Error("Expected invalid signup event to fail")
+	}
+	if result.Kind != KindUnion || len(result.Children) != 1 || result.Children[0].Label != "signup" {
+		t.Errorf("Expected union node with the signup branch only, got: %s", result.Format())
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/email": {"string does not contain @"}}) {
+		t.Errorf("Expected failure at /email, got %v", got)
+	}
+
+	if result, ok := rule.Validate(ctx, testEvent{Type: "batch", Count: 3}); !ok {
+		t.Errorf("Expected valid batch event to pass, got: %s", result.Format())
+	}
+
+	// Test unknown discriminators list the accepted values
+	result, ok = rule.Validate(ctx, testEvent{Type: "delete"})
+	if ok || result.Code != "union.unknown" {
+		t.Errorf("Expected union.unknown failure, got: %s", result.Format())
+	}
+	if want := "unknown type delete (accepted: batch, signup)"; result.Message != want {
+		t.Errorf("Expected message %q, got %q", want, result.Message)
+	}
+}
+
+func TestJSONUnion(t *testing.T) {
+	ctx := context.Background()
+	rule := JSONUnion("type", map[string]*Rule[any]{
+		"ping": JSONObject("ping", Prop("type", JSONString(StringIs("ping")))),
+		"text": JSONObject("text", Prop("body", JSONString(StringLength(1, 140)))),
+	})
+
+	result, _ := rule.Validate(ctx, decodeJSON(t, `{"type": "text", "body": ""}`))
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/body": {"string too short (min: 1, got: 0)"}}) {
+		t.Errorf("Expected failure at /body, got %v", got)
+	}
+	if result, ok := rule.Validate(ctx, decodeJSON(t, `{"kind": "text"}`)); ok || result.Code != "union.unknown" {
+		t.Errorf("Expected missing discriminator to be unknown, got: %s", result.Format())
+	}
+}
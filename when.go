@@ -0,0 +1,87 @@
+package gook
+
+import "context"
+
+// When creates a conditional rule: values passing cond are validated against
+// then, all others against otherwise, which may be nil to accept them
+// The result shows the condition outcome as an "if" node and the branch taken;
+// the other branch is reported as skipped. A condition that errors or times
+// out fails the rule without taking either branch
+func When[T any](cond, then, otherwise *Rule[T]) *Rule[T] {
+	children := []*Rule[T]{cond, then}
+	if otherwise != nil {
+		children = append(children, otherwise)
+	}
+	return &Rule[T]{
+		Label:    "when",
+		Kind:     KindWhen,
+		Children: children,
+	}
+}
+
+func (r *Rule[T]) validateWhen(ctx context.Context, value T) *Result {
+	if len(r.Children) != 2 && len(r.Children) != 3 {
+		return &Result{
+			Status:  StatusFail,
+			Label:   r.Label,
+			Kind:    KindWhen,
+			Message: "when rule must have a condition, a then and an optional else rule",
+			Code:    "rule.invalid_when",
+		}
+	}
+
+	condResult := r.Children[0].validateRecursive(ctx, value)
+	holds := condResult.Status == StatusPass
+
+	// A failing condition is an outcome, not a failure of the value
+	condition := &Result{
+		Status:   StatusPass,
+		Label:    "if",
+		Kind:     KindWhen,
+		Message:  "condition does not hold",
+		Params:   Params{"holds": holds},
+		Children: []*Result{condResult},
+	}
+	if holds {
+		condition.Message = "condition holds"
+	}
+
+	children := []*Result{condition}
+	for _, branch := range r.Children[1:] {
+		children = append(children, skipped(branch))
+	}
+
+	switch condResult.Status {
+	case StatusPass, StatusFail, StatusWarn:
+	default:
+		condition.Status = condResult.Status
+		condition.Message = "condition did not complete"
+		condition.Code = "when.condition_errored"
+		return &Result{
+			Status:   condResult.Status,
+			Label:    r.Label,
+			Kind:     KindWhen,
+			Message:  "when rule errored (condition did not complete)",
+			Code:     "when.condition_errored",
+			Children: children,
+		}
+	}
+
+	result := &Result{
+		Status:   StatusPass,
+		Label:    r.Label,
+		Kind:     KindWhen,
+		Children: children,
+	}
+	taken := 1
+	if !holds {
+		taken = 2
+	}
+	if taken < len(r.Children) {
+		branchResult := r.Children[taken].validateRecursive(ctx, value)
+		children[taken] = branchResult
+		result.Status = branchResult.Status
+		result.Severity = branchResult.Severity
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package gook
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWhen(t *testing.T) {
+	ctx := context.Background()
+	inLund := Field("city", func(a testAddress) string { return a.City }, StringIs("Lund"))
+	zip := func(min, max int) *Rule[testAddress] {
+		return Field("zip", func(a testAddress) string { return a.Zip }, StringLength(min, max))
+	}
+	rule := When(inLund, zip(5, 5), zip(1, 10))
+
+	// Test the then branch is taken when the condition holds
+	result, ok := rule.Validate(ctx, testAddress{City: "Lund", Zip: "221"})
+	if ok {
+		t.Error("Expected short zip in Lund to fail")
+	}
+	if result.Kind != KindWhen || len(result.Children) != 3 {
+		t.Fatalf("Expected when node with condition and both branches, got: %s", result.Format())
+	}
+	if condition := result.Children[0]; condition.Status != StatusPass || condition.Message != "condition holds" {
+		t.Errorf("Expected condition to hold, got: %s", result.Format())
+	}
+	if result.Children[2].Status != StatusSkip {
+		t.Errorf("Expected else branch to be skipped, got: %s", result.Format())
+	}
+	if got := result.Flatten(); !reflect.DeepEqual(got, map[string][]string{"/zip": {"string too short (min: 5, got: 3)"}}) {
+		t.Errorf("Expected only the branch failure, got %v", got)
+	}
+
+	// Test the else branch is taken when it does not, without reporting the condition
+	result, ok = rule.Validate(ctx, testAddress{City: "Ystad", Zip: "271"})
+	if !ok {
+		t.Errorf("Expected zip outside Lund to pass, got: %s", result.Format())
+	}
+	if result.Children[0].Message != "condition does not hold" || result.Children[1].Status != StatusSkip {
+		t.Errorf("Expected else branch to be taken, got: %s", result.Format())
+	}
+
+	// Test a missing else branch accepts the value
+	if result, ok := When(inLund, zip(5, 5), nil).Validate(ctx, testAddress{City: "Ystad"}); !ok || len(result.Children) != 2 {
+		t.Errorf("Expected value to pass without an else branch, got: %s", result.Format())
+	}
+}
+
+func TestWhenConditionError(t *testing.T) {
+	broken := Test("broken", func(ctx context.Context, s string) error {
+		panic(errors.New("boom"))
+	})
+	result, ok := When(broken, StringLength(1, 2), nil).Validate(context.Background(), "abc")
+	if ok || result.Status != StatusError || result.Code != "when.condition_errored" {
+		t.Errorf("Expected errored condition to error the rule, got: %s", result.Format())
+	}
+	if result.Children[1].Status != StatusSkip {
+		t.Errorf("Expected branches to be skipped, got: %s", result.Format())
+	}
+}